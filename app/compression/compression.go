@@ -0,0 +1,238 @@
+// Package compression implements the codecs Kafka uses to compress the
+// records of a record batch: gzip, snappy, lz4 and zstd.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/snappy/xerial"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Codec is a compression codec, as stored in the lowest three bits of the
+// record batch attributes.
+type Codec int8
+
+const (
+	None   Codec = 0
+	Gzip   Codec = 1
+	Snappy Codec = 2
+	LZ4    Codec = 3
+	Zstd   Codec = 4
+)
+
+// TypeProducer is the compression.type that keeps the codec chosen by the producer.
+const TypeProducer = "producer"
+
+// ErrUnsupportedCodec is returned for codec ids Kafka does not define.
+var ErrUnsupportedCodec = errors.New("unsupported compression codec")
+
+// ErrTooLarge is returned when data decompresses to more than the allowed size.
+var ErrTooLarge = errors.New("decompressed data too large")
+
+// Types lists the accepted compression.type values.
+var Types = []string{"uncompressed", "zstd", "lz4", "snappy", "gzip", TypeProducer}
+
+func (c Codec) String() string {
+	switch c {
+	case None:
+		return "none"
+	case Gzip:
+		return "gzip"
+	case Snappy:
+		return "snappy"
+	case LZ4:
+		return "lz4"
+	case Zstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", int8(c))
+	}
+}
+
+// ParseType parses a compression.type config value. producer is set for
+// "producer", in which case batches keep the codec they were produced with.
+func ParseType(value string) (codec Codec, producer bool, err error) {
+	switch value {
+	case TypeProducer:
+		return None, true, nil
+	case "uncompressed":
+		return None, false, nil
+	case "gzip":
+		return Gzip, false, nil
+	case "snappy":
+		return Snappy, false, nil
+	case "lz4":
+		return LZ4, false, nil
+	case "zstd":
+		return Zstd, false, nil
+	default:
+		return None, false, fmt.Errorf("invalid compression type %q, must be one of: %s", value, strings.Join(Types, ", "))
+	}
+}
+
+// zstdEncoder is shared; its EncodeAll method is safe for concurrent use.
+var zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+	return zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+})
+
+// zstdDecoders are streaming decoders, which, unlike DecodeAll, let the output
+// be read up to a limit. With a concurrency of 1 they decode synchronously.
+var zstdDecoders = sync.Pool{
+	New: func() any {
+		// NewReader only fails for invalid options.
+		dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		return dec
+	},
+}
+
+// Compress compresses data with codec. None returns data unchanged.
+func Compress(codec Codec, data []byte) ([]byte, error) {
+	switch codec {
+	case None:
+		return data, nil
+	case Gzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to gzip: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to gzip: %w", err)
+		}
+		return buf.Bytes(), nil
+	case Snappy:
+		// Kafka's Java clients write snappy in the xerial framing.
+		return xerial.Encode(nil, data), nil
+	case LZ4:
+		var buf bytes.Buffer
+		w := lz4.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to compress lz4: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress lz4: %w", err)
+		}
+		return buf.Bytes(), nil
+	case Zstd:
+		enc, err := zstdEncoder()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		return enc.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedCodec, int8(codec))
+	}
+}
+
+// Decompress decompresses data compressed with codec. None returns data
+// unchanged. Data that decompresses to more than maxSize bytes fails with
+// ErrTooLarge; no more than maxSize+1 bytes are ever decompressed.
+func Decompress(codec Codec, data []byte, maxSize int) ([]byte, error) {
+	switch codec {
+	case None:
+		return data, nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip header: %w", err)
+		}
+		out, err := readAll(r, maxSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to gunzip: %w", err)
+		}
+		return out, nil
+	case Snappy:
+		// Snappy blocks store their decompressed length, which is checked
+		// before anything is decompressed.
+		n, err := snappyDecodedLen(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress snappy: %w", err)
+		}
+		if n > maxSize {
+			return nil, fmt.Errorf("failed to decompress snappy: %w: %d bytes, limit %d", ErrTooLarge, n, maxSize)
+		}
+		// Both raw snappy blocks and the xerial framing are accepted.
+		out, err := xerial.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress snappy: %w", err)
+		}
+		return out, nil
+	case LZ4:
+		out, err := readAll(lz4.NewReader(bytes.NewReader(data)), maxSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress lz4: %w", err)
+		}
+		return out, nil
+	case Zstd:
+		dec := zstdDecoders.Get().(*zstd.Decoder)
+		if dec == nil {
+			return nil, errors.New("failed to create zstd decoder")
+		}
+		defer func() {
+			dec.Reset(nil) // releases data
+			zstdDecoders.Put(dec)
+		}()
+		if err := dec.Reset(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd: %w", err)
+		}
+		out, err := readAll(dec, maxSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd: %w", err)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedCodec, int8(codec))
+	}
+}
+
+// readAll reads r to the end through an io.LimitReader of maxSize+1 bytes, so
+// that output beyond maxSize is detected without reading more of it.
+func readAll(r io.Reader, maxSize int) ([]byte, error) {
+	out, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > maxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, maxSize)
+	}
+	return out, nil
+}
+
+// xerialMagic starts snappy data in the xerial framing, followed by a version
+// and a compatible version, then chunks each prefixed with its 32-bit length.
+var xerialMagic = []byte{0x82, 'S', 'N', 'A', 'P', 'P', 'Y', 0}
+
+const xerialHeaderSize = 16
+
+// snappyDecodedLen returns the decompressed size of a raw snappy block or of
+// the chunks of xerial-framed snappy data.
+func snappyDecodedLen(data []byte) (int, error) {
+	if !bytes.HasPrefix(data, xerialMagic) {
+		return s2.DecodedLen(data)
+	}
+	total := 0
+	for pos := xerialHeaderSize; pos+4 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[pos:]))
+		pos += 4
+		if size > len(data)-pos {
+			return 0, xerial.ErrMalformed
+		}
+		n, err := s2.DecodedLen(data[pos : pos+size])
+		if err != nil {
+			return 0, err
+		}
+		total += n
+		pos += size
+	}
+	return total, nil
+}
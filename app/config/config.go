@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"log/slog" // Import slog for logging
+	"math"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper" // Import viper
 )
 
@@ -12,14 +16,85 @@ import (
 type Config struct {
 	Host string
 	Port int
+	// NodeID is the broker id reported to clients in Metadata responses.
+	NodeID int32
+	// AdvertisedHost is the host clients should use to connect to this broker.
+	AdvertisedHost string
+	// ClusterID is the cluster id reported to clients, empty if unknown.
+	ClusterID string
+	// NumPartitions is the partition count of topics created without one.
+	NumPartitions int32
+	// DefaultReplicationFactor is the replication factor of topics created without one.
+	DefaultReplicationFactor int16
+	// LogDirs are the directories partition logs are spread across. The cluster
+	// metadata log lives in the first one.
+	LogDirs []string
+	// LogSegmentBytes is the size at which a partition log rolls to a new segment.
+	LogSegmentBytes int64
+	// LogRollMs is the age at which a partition log rolls to a new segment.
+	LogRollMs time.Duration
+	// LogIndexIntervalBytes is how many bytes are appended between offset index entries.
+	LogIndexIntervalBytes int
+	// MessageMaxBytes is the largest record batch a producer may send, and
+	// the largest size its records may decompress to.
+	MessageMaxBytes int
+	// RecordDumpTopics are the topics whose produced and fetched records are
+	// logged for debugging, "*" for all topics. Empty disables the dump.
+	RecordDumpTopics []string
+	// RecordDumpMaxBytes is how many bytes of keys, values and header values are logged; 0 logs all of them.
+	RecordDumpMaxBytes int
+	// RecordDumpRedact lists the record parts logged only by their size: "keys", "values" and "headers".
+	RecordDumpRedact []string
 }
 
 // Constants for configuration keys
 const (
-	KeyHost = "kafka.host"
-	KeyPort = "kafka.port"
+	KeyHost           = "kafka.host"
+	KeyPort           = "kafka.port"
+	KeyNodeID         = "kafka.node.id"
+	KeyAdvertisedHost = "kafka.advertised.host"
+	KeyClusterID      = "kafka.cluster.id"
+
+	KeyNumPartitions            = "kafka.num.partitions"
+	KeyDefaultReplicationFactor = "kafka.default.replication.factor"
+	KeyLogDirs                  = "kafka.log.dirs"
+	KeyLogSegmentBytes          = "kafka.log.segment.bytes"
+	KeyLogRollMs                = "kafka.log.roll.ms"
+	KeyLogIndexIntervalBytes    = "kafka.log.index.interval.bytes"
+	KeyMessageMaxBytes          = "kafka.message.max.bytes"
+
+	KeyRecordDumpTopics   = "kafka.record.dump.topics"
+	KeyRecordDumpMaxBytes = "kafka.record.dump.max.bytes"
+	KeyRecordDumpRedact   = "kafka.record.dump.redact"
+
+	// KeyConfigFile names an optional configuration file (yaml, json, toml or properties).
+	KeyConfigFile = "kafka.config"
 )
 
+// DefaultLogDir is where partition logs are kept when log.dirs is not configured.
+const DefaultLogDir = "/tmp/kraft-combined-logs"
+
+// flagUsages lists the settings that can also be given on the command line. Flags
+// are named after the key without its "kafka." prefix, e.g. --log.dirs.
+var flagUsages = map[string]string{
+	KeyHost:                     "address to listen on",
+	KeyPort:                     "port to listen on",
+	KeyNodeID:                   "broker id reported to clients",
+	KeyAdvertisedHost:           "host clients should connect to",
+	KeyClusterID:                "cluster id reported to clients",
+	KeyNumPartitions:            "default partition count of new topics",
+	KeyDefaultReplicationFactor: "default replication factor of new topics",
+	KeyLogDirs:                  "comma-separated list of log directories",
+	KeyLogSegmentBytes:          "size at which log segments are rolled",
+	KeyLogRollMs:                "age in milliseconds at which log segments are rolled",
+	KeyLogIndexIntervalBytes:    "bytes appended between offset index entries",
+	KeyMessageMaxBytes:          "largest record batch accepted from producers, compressed or not",
+	KeyRecordDumpTopics:         "comma-separated list of topics whose records are logged, * for all",
+	KeyRecordDumpMaxBytes:       "bytes of record keys and values logged, 0 for all",
+	KeyRecordDumpRedact:         "comma-separated list of record parts not logged: keys, values, headers",
+	KeyConfigFile:               "path to a configuration file",
+}
+
 // New creates a new Config using viper for loading values
 func New(log *slog.Logger) (*Config, error) {
 	v := viper.New()
@@ -27,6 +102,19 @@ func New(log *slog.Logger) (*Config, error) {
 	// 1. Set Defaults
 	v.SetDefault(KeyHost, "0.0.0.0")
 	v.SetDefault(KeyPort, 9092)
+	v.SetDefault(KeyNodeID, 1)
+	v.SetDefault(KeyAdvertisedHost, "localhost")
+	v.SetDefault(KeyClusterID, "")
+	v.SetDefault(KeyNumPartitions, 1)
+	v.SetDefault(KeyDefaultReplicationFactor, 1)
+	v.SetDefault(KeyLogDirs, DefaultLogDir)
+	v.SetDefault(KeyLogSegmentBytes, 1<<30)
+	v.SetDefault(KeyLogRollMs, int64(7*24*time.Hour/time.Millisecond))
+	v.SetDefault(KeyLogIndexIntervalBytes, 4096)
+	v.SetDefault(KeyMessageMaxBytes, 1048588)
+	v.SetDefault(KeyRecordDumpTopics, "")
+	v.SetDefault(KeyRecordDumpMaxBytes, 128)
+	v.SetDefault(KeyRecordDumpRedact, "")
 
 	// 2. Configure Environment Variables
 	// Allow viper to read KAFKA_HOST and KAFKA_PORT
@@ -34,18 +122,115 @@ func New(log *slog.Logger) (*Config, error) {
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // Replace '.' with '_' for env vars (e.g., kafka.host -> KAFKA_HOST)
 	v.AutomaticEnv()                                   // Read matching environment variables
 
-	// 3. Get values
+	// 3. Configure command line flags, which take precedence over everything else.
+	// Positional arguments (e.g. a server.properties path) are ignored.
+	flags := pflag.NewFlagSet(os.Args[0], pflag.ContinueOnError)
+	for key, usage := range flagUsages {
+		name := strings.TrimPrefix(key, "kafka.")
+		flags.String(name, "", usage)
+		if err := v.BindPFlag(key, flags.Lookup(name)); err != nil {
+			return nil, fmt.Errorf("failed to bind flag %s: %w", name, err)
+		}
+	}
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		return nil, fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	// 4. Read the configuration file, if any. Its keys use the same names as
+	// the settings above, e.g. "kafka.log.dirs".
+	if configFile := v.GetString(KeyConfigFile); configFile != "" {
+		v.SetConfigFile(configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", configFile, err)
+		}
+	}
+
+	// 5. Get values
 	host := v.GetString(KeyHost)
 	port := v.GetInt(KeyPort)
+	nodeID := v.GetInt32(KeyNodeID)
+	advertisedHost := v.GetString(KeyAdvertisedHost)
+	clusterID := v.GetString(KeyClusterID)
+	numPartitions := v.GetInt32(KeyNumPartitions)
+	defaultReplicationFactor := int16(v.GetInt(KeyDefaultReplicationFactor))
+	logDirs := splitList(v.GetString(KeyLogDirs))
+	logSegmentBytes := v.GetInt64(KeyLogSegmentBytes)
+	logRollMs := v.GetInt64(KeyLogRollMs)
+	logIndexIntervalBytes := v.GetInt(KeyLogIndexIntervalBytes)
+	messageMaxBytes := v.GetInt(KeyMessageMaxBytes)
+	recordDumpTopics := splitList(v.GetString(KeyRecordDumpTopics))
+	recordDumpMaxBytes := v.GetInt(KeyRecordDumpMaxBytes)
+	recordDumpRedact := splitList(v.GetString(KeyRecordDumpRedact))
+	if numPartitions < 1 {
+		return nil, fmt.Errorf("%s must be at least 1, got %d", KeyNumPartitions, numPartitions)
+	}
+	if defaultReplicationFactor < 1 {
+		return nil, fmt.Errorf("%s must be at least 1, got %d", KeyDefaultReplicationFactor, defaultReplicationFactor)
+	}
+	if len(logDirs) == 0 {
+		return nil, fmt.Errorf("%s must name at least one directory", KeyLogDirs)
+	}
+	// Positions in the offset index are 32 bit, so segments cannot grow larger.
+	if logSegmentBytes < 1024 || logSegmentBytes > math.MaxInt32 {
+		return nil, fmt.Errorf("%s must be between 1024 and %d, got %d", KeyLogSegmentBytes, math.MaxInt32, logSegmentBytes)
+	}
+	if logRollMs < 1 {
+		return nil, fmt.Errorf("%s must be at least 1, got %d", KeyLogRollMs, logRollMs)
+	}
+	if logIndexIntervalBytes < 0 {
+		return nil, fmt.Errorf("%s must not be negative, got %d", KeyLogIndexIntervalBytes, logIndexIntervalBytes)
+	}
+	if messageMaxBytes < 0 || messageMaxBytes > math.MaxInt32 {
+		return nil, fmt.Errorf("%s must be between 0 and %d, got %d", KeyMessageMaxBytes, math.MaxInt32, messageMaxBytes)
+	}
+	if recordDumpMaxBytes < 0 {
+		return nil, fmt.Errorf("%s must not be negative, got %d", KeyRecordDumpMaxBytes, recordDumpMaxBytes)
+	}
+	for _, part := range recordDumpRedact {
+		if part != "keys" && part != "values" && part != "headers" {
+			return nil, fmt.Errorf("%s must only list keys, values and headers, got %q", KeyRecordDumpRedact, part)
+		}
+	}
 
-	log.Info("Configuration loaded", "host", host, "port", port)
+	log.Info("Configuration loaded", "host", host, "port", port, "nodeID", nodeID, "advertisedHost", advertisedHost, "clusterID", clusterID,
+		"numPartitions", numPartitions, "defaultReplicationFactor", defaultReplicationFactor, "logDirs", logDirs,
+		"logSegmentBytes", logSegmentBytes, "logRollMs", logRollMs, "messageMaxBytes", messageMaxBytes, "recordDumpTopics", recordDumpTopics)
 
 	return &Config{
-		Host: host,
-		Port: port,
+		Host:           host,
+		Port:           port,
+		NodeID:         nodeID,
+		AdvertisedHost: advertisedHost,
+		ClusterID:      clusterID,
+
+		NumPartitions:            numPartitions,
+		DefaultReplicationFactor: defaultReplicationFactor,
+		LogDirs:                  logDirs,
+		LogSegmentBytes:          logSegmentBytes,
+		LogRollMs:                time.Duration(logRollMs) * time.Millisecond,
+		LogIndexIntervalBytes:    logIndexIntervalBytes,
+		MessageMaxBytes:          messageMaxBytes,
+		RecordDumpTopics:         recordDumpTopics,
+		RecordDumpMaxBytes:       recordDumpMaxBytes,
+		RecordDumpRedact:         recordDumpRedact,
 	}, nil
 }
 
+// splitList splits a comma-separated setting, dropping blanks and duplicates.
+func splitList(value string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	return items
+}
+
 // Address returns the full address string for the server
 func (c *Config) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
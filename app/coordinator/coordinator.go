@@ -0,0 +1,474 @@
+package coordinator
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+	"github.com/google/uuid"
+)
+
+// Session timeout bounds accepted from members, matching Kafka's
+// group.min.session.timeout.ms and group.max.session.timeout.ms defaults.
+const (
+	MinSessionTimeout = 6 * time.Second
+	MaxSessionTimeout = 30 * time.Minute
+)
+
+// GroupCoordinator manages consumer group membership, rebalances and committed offsets.
+type GroupCoordinator struct {
+	mu     sync.Mutex
+	log    *slog.Logger
+	logs   *storage.LogManager
+	groups map[string]*group
+	// offsets caches the committed offsets of every group, including groups without members.
+	offsets map[string]map[TopicPartition]OffsetAndMetadata
+}
+
+// NewGroupCoordinator creates a coordinator with no groups. Call LoadOffsets
+// to restore previously committed offsets.
+func NewGroupCoordinator(log *slog.Logger, logs *storage.LogManager) *GroupCoordinator {
+	return &GroupCoordinator{
+		log:     log,
+		logs:    logs,
+		groups:  make(map[string]*group),
+		offsets: make(map[string]map[TopicPartition]OffsetAndMetadata),
+	}
+}
+
+// JoinParams holds the fields of a JoinGroup request.
+type JoinParams struct {
+	GroupID          string
+	MemberID         string
+	GroupInstanceID  *string
+	ClientID         string
+	SessionTimeout   time.Duration
+	RebalanceTimeout time.Duration
+	ProtocolType     string
+	Protocols        []Protocol
+	// RequireKnownMemberID makes new members rejoin with an assigned member id (JoinGroup v4+).
+	RequireKnownMemberID bool
+}
+
+// JoinResult is the outcome of a JoinGroup request.
+type JoinResult struct {
+	ErrorCode    int16
+	GenerationID int32
+	ProtocolType *string
+	ProtocolName *string
+	LeaderID     string
+	MemberID     string
+	// Members is only set for the group leader.
+	Members []JoinedMember
+}
+
+// JoinedMember describes a group member to the leader.
+type JoinedMember struct {
+	MemberID        string
+	GroupInstanceID *string
+	Metadata        []byte
+}
+
+// SyncParams holds the fields of a SyncGroup request.
+type SyncParams struct {
+	GroupID         string
+	GenerationID    int32
+	MemberID        string
+	GroupInstanceID *string
+	ProtocolType    *string
+	ProtocolName    *string
+	// Assignments is only sent by the leader, keyed by member id.
+	Assignments map[string][]byte
+}
+
+// SyncResult is the outcome of a SyncGroup request.
+type SyncResult struct {
+	ErrorCode    int16
+	ProtocolType *string
+	ProtocolName *string
+	Assignment   []byte
+}
+
+// LeavingMember identifies a member leaving its group.
+type LeavingMember struct {
+	MemberID        string
+	GroupInstanceID *string
+}
+
+// JoinGroup adds or updates a member and starts a rebalance. The returned channel
+// receives the result once the join phase of the rebalance completes.
+func (c *GroupCoordinator) JoinGroup(params JoinParams) <-chan JoinResult {
+	result := make(chan JoinResult, 1)
+	fail := func(errorCode int16) <-chan JoinResult {
+		result <- JoinResult{ErrorCode: errorCode, GenerationID: -1, MemberID: params.MemberID}
+		return result
+	}
+	if params.GroupID == "" {
+		return fail(protocol.ErrorCodeInvalidGroupID)
+	}
+	if params.SessionTimeout < MinSessionTimeout || params.SessionTimeout > MaxSessionTimeout {
+		return fail(protocol.ErrorCodeInvalidSessionTimeout)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, ok := c.groups[params.GroupID]
+	if !ok {
+		g = newGroup(params.GroupID)
+		c.groups[params.GroupID] = g
+	}
+
+	memberID := params.MemberID
+	if memberID == "" && params.GroupInstanceID != nil {
+		memberID = g.staticMembers[*params.GroupInstanceID]
+	}
+	if !g.supportsProtocols(memberID, params.ProtocolType, params.Protocols) {
+		c.maybeRemoveGroup(g)
+		return fail(protocol.ErrorCodeInconsistentGroupProtocol)
+	}
+
+	switch {
+	case memberID == "":
+		memberID = params.ClientID + "-" + uuid.NewString()
+		if params.RequireKnownMemberID && params.GroupInstanceID == nil {
+			c.addPendingMember(g, memberID, params.SessionTimeout)
+			result <- JoinResult{ErrorCode: protocol.ErrorCodeMemberIDRequired, GenerationID: -1, MemberID: memberID}
+			return result
+		}
+	case g.members[memberID] == nil:
+		if _, pending := g.pendingMembers[memberID]; !pending {
+			c.maybeRemoveGroup(g)
+			return fail(protocol.ErrorCodeUnknownMemberID)
+		}
+		delete(g.pendingMembers, memberID)
+	}
+
+	m := g.members[memberID]
+	if m == nil {
+		m = &member{id: memberID}
+		g.members[memberID] = m
+		c.log.Info("Member joined group", "groupID", g.id, "memberID", memberID)
+	}
+	if m.awaitingJoin != nil {
+		// A member rejoining while its previous join is pending replaces the old request.
+		m.awaitingJoin <- JoinResult{ErrorCode: protocol.ErrorCodeUnknownMemberID, GenerationID: -1, MemberID: memberID}
+	}
+	m.groupInstanceID = params.GroupInstanceID
+	if params.GroupInstanceID != nil {
+		g.staticMembers[*params.GroupInstanceID] = memberID
+	}
+	m.clientID = params.ClientID
+	m.sessionTimeout = params.SessionTimeout
+	m.rebalanceTimeout = params.RebalanceTimeout
+	m.protocols = params.Protocols
+	m.awaitingJoin = result
+	g.protocolType = params.ProtocolType
+	// The session timer is paused while the member waits for the rebalance.
+	stopTimer(m.sessionTimer)
+
+	if g.state != PreparingRebalance {
+		c.prepareRebalance(g)
+	}
+	c.maybeCompleteJoin(g)
+	return result
+}
+
+// SyncGroup delivers the leader's assignment. The returned channel receives the
+// member's assignment once the leader has synced.
+func (c *GroupCoordinator) SyncGroup(params SyncParams) <-chan SyncResult {
+	result := make(chan SyncResult, 1)
+	fail := func(errorCode int16) <-chan SyncResult {
+		result <- SyncResult{ErrorCode: errorCode, Assignment: []byte{}}
+		return result
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, ok := c.groups[params.GroupID]
+	if !ok {
+		return fail(protocol.ErrorCodeUnknownMemberID)
+	}
+	m, ok := g.members[params.MemberID]
+	if !ok {
+		return fail(protocol.ErrorCodeUnknownMemberID)
+	}
+	if params.GenerationID != g.generationID {
+		return fail(protocol.ErrorCodeIllegalGeneration)
+	}
+	if (params.ProtocolType != nil && *params.ProtocolType != g.protocolType) ||
+		(params.ProtocolName != nil && *params.ProtocolName != g.protocolName) {
+		return fail(protocol.ErrorCodeInconsistentGroupProtocol)
+	}
+
+	switch g.state {
+	case PreparingRebalance:
+		return fail(protocol.ErrorCodeRebalanceInProgress)
+	case CompletingRebalance:
+		m.awaitingSync = result
+		c.resetSessionTimer(g, m)
+		if params.MemberID == g.leaderID {
+			for id, member := range g.members {
+				member.assignment = params.Assignments[id]
+			}
+			g.state = Stable
+			c.log.Info("Group is stable", "groupID", g.id, "generationID", g.generationID)
+			for _, member := range g.members {
+				if member.awaitingSync != nil {
+					member.awaitingSync <- c.syncResult(g, member)
+					member.awaitingSync = nil
+				}
+			}
+		}
+		return result
+	case Stable:
+		c.resetSessionTimer(g, m)
+		result <- c.syncResult(g, m)
+		return result
+	default:
+		return fail(protocol.ErrorCodeUnknownMemberID)
+	}
+}
+
+// Heartbeat keeps a member's session alive and tells it whether a rebalance is in progress.
+func (c *GroupCoordinator) Heartbeat(groupID string, generationID int32, memberID string) int16 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	g, ok := c.groups[groupID]
+	if !ok {
+		return protocol.ErrorCodeUnknownMemberID
+	}
+	m, ok := g.members[memberID]
+	if !ok {
+		return protocol.ErrorCodeUnknownMemberID
+	}
+	if generationID != g.generationID {
+		return protocol.ErrorCodeIllegalGeneration
+	}
+	switch g.state {
+	case PreparingRebalance:
+		return protocol.ErrorCodeRebalanceInProgress
+	case CompletingRebalance, Stable:
+		c.resetSessionTimer(g, m)
+		return protocol.ErrorCodeNone
+	default:
+		return protocol.ErrorCodeUnknownMemberID
+	}
+}
+
+// LeaveGroup removes members from a group and triggers a rebalance for the remaining ones.
+// It returns a group-level error code and one error code per leaving member.
+func (c *GroupCoordinator) LeaveGroup(groupID string, leaving []LeavingMember) (int16, []int16) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	errorCodes := make([]int16, len(leaving))
+	g, ok := c.groups[groupID]
+	if !ok {
+		for i := range errorCodes {
+			errorCodes[i] = protocol.ErrorCodeUnknownMemberID
+		}
+		return protocol.ErrorCodeUnknownMemberID, errorCodes
+	}
+	removed := false
+	for i, l := range leaving {
+		memberID := l.MemberID
+		if memberID == "" && l.GroupInstanceID != nil {
+			memberID = g.staticMembers[*l.GroupInstanceID]
+		}
+		m, ok := g.members[memberID]
+		if !ok {
+			errorCodes[i] = protocol.ErrorCodeUnknownMemberID
+			continue
+		}
+		c.log.Info("Member left group", "groupID", g.id, "memberID", memberID)
+		c.removeMember(g, m)
+		removed = true
+	}
+	if removed {
+		c.onMembershipChange(g)
+	}
+	return protocol.ErrorCodeNone, errorCodes
+}
+
+func (c *GroupCoordinator) syncResult(g *group, m *member) SyncResult {
+	protocolType, protocolName := g.protocolType, g.protocolName
+	assignment := m.assignment
+	if assignment == nil {
+		assignment = []byte{}
+	}
+	return SyncResult{
+		ErrorCode:    protocol.ErrorCodeNone,
+		ProtocolType: &protocolType,
+		ProtocolName: &protocolName,
+		Assignment:   assignment,
+	}
+}
+
+// prepareRebalance moves the group to PreparingRebalance and waits up to the
+// largest rebalance timeout for the members to rejoin.
+func (c *GroupCoordinator) prepareRebalance(g *group) {
+	for _, m := range g.members {
+		if m.awaitingSync != nil {
+			m.awaitingSync <- SyncResult{ErrorCode: protocol.ErrorCodeRebalanceInProgress, Assignment: []byte{}}
+			m.awaitingSync = nil
+		}
+	}
+	g.state = PreparingRebalance
+	c.log.Info("Preparing rebalance", "groupID", g.id, "generationID", g.generationID, "members", len(g.members))
+
+	stopTimer(g.rebalanceTimer)
+	var timer *time.Timer
+	timer = time.AfterFunc(g.maxRebalanceTimeout(), func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if g.rebalanceTimer == timer && g.state == PreparingRebalance {
+			c.completeJoin(g)
+		}
+	})
+	g.rebalanceTimer = timer
+}
+
+func (c *GroupCoordinator) maybeCompleteJoin(g *group) {
+	if g.state == PreparingRebalance && g.allMembersJoined() {
+		c.completeJoin(g)
+	}
+}
+
+// completeJoin ends the join phase: members that did not rejoin are removed, a
+// new generation starts and every joined member receives its JoinGroup response.
+func (c *GroupCoordinator) completeJoin(g *group) {
+	stopTimer(g.rebalanceTimer)
+	g.rebalanceTimer = nil
+	for _, m := range g.members {
+		if m.awaitingJoin == nil {
+			c.log.Info("Removing member that did not rejoin", "groupID", g.id, "memberID", m.id)
+			c.removeMember(g, m)
+		}
+	}
+
+	g.generationID++
+	if len(g.members) == 0 {
+		g.state = Empty
+		g.protocolName = ""
+		g.leaderID = ""
+		c.log.Info("Group is empty", "groupID", g.id, "generationID", g.generationID)
+		c.maybeRemoveGroup(g)
+		return
+	}
+
+	ids := g.memberIDs()
+	if _, ok := g.members[g.leaderID]; !ok {
+		g.leaderID = ids[0]
+	}
+	g.protocolName = g.selectProtocol()
+	g.state = CompletingRebalance
+	c.log.Info("Completing rebalance", "groupID", g.id, "generationID", g.generationID, "leader", g.leaderID, "protocol", g.protocolName)
+
+	var leaderMembers []JoinedMember
+	for _, id := range ids {
+		m := g.members[id]
+		leaderMembers = append(leaderMembers, JoinedMember{
+			MemberID:        m.id,
+			GroupInstanceID: m.groupInstanceID,
+			Metadata:        m.protocolMetadata(g.protocolName),
+		})
+	}
+	for _, id := range ids {
+		m := g.members[id]
+		protocolType, protocolName := g.protocolType, g.protocolName
+		result := JoinResult{
+			ErrorCode:    protocol.ErrorCodeNone,
+			GenerationID: g.generationID,
+			ProtocolType: &protocolType,
+			ProtocolName: &protocolName,
+			LeaderID:     g.leaderID,
+			MemberID:     m.id,
+			Members:      []JoinedMember{},
+		}
+		if m.id == g.leaderID {
+			result.Members = leaderMembers
+		}
+		m.awaitingJoin <- result
+		m.awaitingJoin = nil
+		c.resetSessionTimer(g, m)
+	}
+}
+
+// onMembershipChange reacts to members leaving or expiring.
+func (c *GroupCoordinator) onMembershipChange(g *group) {
+	switch g.state {
+	case Stable, CompletingRebalance:
+		c.prepareRebalance(g)
+		c.maybeCompleteJoin(g)
+	case PreparingRebalance:
+		c.maybeCompleteJoin(g)
+	}
+}
+
+func (c *GroupCoordinator) removeMember(g *group, m *member) {
+	stopTimer(m.sessionTimer)
+	delete(g.members, m.id)
+	if m.groupInstanceID != nil && g.staticMembers[*m.groupInstanceID] == m.id {
+		delete(g.staticMembers, *m.groupInstanceID)
+	}
+	if m.awaitingJoin != nil {
+		m.awaitingJoin <- JoinResult{ErrorCode: protocol.ErrorCodeUnknownMemberID, GenerationID: -1, MemberID: m.id}
+		m.awaitingJoin = nil
+	}
+	if m.awaitingSync != nil {
+		m.awaitingSync <- SyncResult{ErrorCode: protocol.ErrorCodeUnknownMemberID, Assignment: []byte{}}
+		m.awaitingSync = nil
+	}
+}
+
+// addPendingMember remembers a member id handed out with MEMBER_ID_REQUIRED
+// until the member rejoins with it or its session timeout passes.
+func (c *GroupCoordinator) addPendingMember(g *group, memberID string, sessionTimeout time.Duration) {
+	g.pendingMembers[memberID] = struct{}{}
+	time.AfterFunc(sessionTimeout, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := g.pendingMembers[memberID]; ok {
+			delete(g.pendingMembers, memberID)
+			c.maybeRemoveGroup(g)
+		}
+	})
+}
+
+// resetSessionTimer (re)starts the session timeout of a member.
+func (c *GroupCoordinator) resetSessionTimer(g *group, m *member) {
+	stopTimer(m.sessionTimer)
+	var timer *time.Timer
+	timer = time.AfterFunc(m.sessionTimeout, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if m.sessionTimer != timer || g.members[m.id] != m {
+			return
+		}
+		c.log.Info("Member session expired", "groupID", g.id, "memberID", m.id)
+		c.removeMember(g, m)
+		c.onMembershipChange(g)
+	})
+	m.sessionTimer = timer
+}
+
+// maybeRemoveGroup drops an empty group from the coordinator and marks it Dead.
+func (c *GroupCoordinator) maybeRemoveGroup(g *group) {
+	if g.state != Empty || len(g.members) > 0 || len(g.pendingMembers) > 0 || c.groups[g.id] != g {
+		return
+	}
+	g.state = Dead
+	delete(c.groups, g.id)
+	c.log.Info("Removed group", "groupID", g.id)
+}
+
+func stopTimer(t *time.Timer) {
+	if t != nil {
+		t.Stop()
+	}
+}
@@ -0,0 +1,186 @@
+package coordinator
+
+import (
+	"slices"
+	"time"
+)
+
+// GroupState is the state of a consumer group in the classic rebalance protocol.
+type GroupState int
+
+const (
+	// Empty means the group has no members, but may still have committed offsets.
+	Empty GroupState = iota
+	// PreparingRebalance means the group is waiting for members to (re)join.
+	PreparingRebalance
+	// CompletingRebalance means the group is waiting for the leader to send the assignment.
+	CompletingRebalance
+	// Stable means every member has received its assignment.
+	Stable
+	// Dead means the group has been removed from the coordinator.
+	Dead
+)
+
+func (s GroupState) String() string {
+	switch s {
+	case Empty:
+		return "Empty"
+	case PreparingRebalance:
+		return "PreparingRebalance"
+	case CompletingRebalance:
+		return "CompletingRebalance"
+	case Stable:
+		return "Stable"
+	case Dead:
+		return "Dead"
+	default:
+		return "Unknown"
+	}
+}
+
+// Protocol is an assignment protocol supported by a member, with the member's
+// protocol-specific metadata (e.g. the subscribed topics).
+type Protocol struct {
+	Name     string
+	Metadata []byte
+}
+
+type member struct {
+	id               string
+	groupInstanceID  *string
+	clientID         string
+	sessionTimeout   time.Duration
+	rebalanceTimeout time.Duration
+	protocols        []Protocol
+	assignment       []byte
+
+	// awaitingJoin is set while the member waits for the join phase to complete.
+	awaitingJoin chan JoinResult
+	// awaitingSync is set while the member waits for the leader's assignment.
+	awaitingSync chan SyncResult
+	// sessionTimer expires the member when no heartbeat arrives within the session timeout.
+	sessionTimer *time.Timer
+}
+
+func (m *member) supportsProtocol(name string) bool {
+	return slices.ContainsFunc(m.protocols, func(p Protocol) bool { return p.Name == name })
+}
+
+func (m *member) protocolMetadata(name string) []byte {
+	for _, p := range m.protocols {
+		if p.Name == name {
+			return p.Metadata
+		}
+	}
+	return nil
+}
+
+// group is the coordinator's view of a consumer group. It is guarded by the coordinator mutex.
+type group struct {
+	id           string
+	state        GroupState
+	generationID int32
+	protocolType string
+	protocolName string
+	leaderID     string
+	members      map[string]*member
+	// pendingMembers holds member ids handed out with MEMBER_ID_REQUIRED that have not joined yet.
+	pendingMembers map[string]struct{}
+	// staticMembers maps group instance ids to member ids.
+	staticMembers  map[string]string
+	rebalanceTimer *time.Timer
+}
+
+func newGroup(id string) *group {
+	return &group{
+		id:             id,
+		state:          Empty,
+		members:        make(map[string]*member),
+		pendingMembers: make(map[string]struct{}),
+		staticMembers:  make(map[string]string),
+	}
+}
+
+// memberIDs returns the member ids in a stable order.
+func (g *group) memberIDs() []string {
+	ids := make([]string, 0, len(g.members))
+	for id := range g.members {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// supportsProtocols reports whether a member with the given protocols can join the group.
+// The member identified by memberID is ignored, since its protocols are being replaced.
+func (g *group) supportsProtocols(memberID string, protocolType string, protocols []Protocol) bool {
+	if protocolType == "" || len(protocols) == 0 {
+		return false
+	}
+	others := 0
+	for id := range g.members {
+		if id != memberID {
+			others++
+		}
+	}
+	if others == 0 {
+		return true
+	}
+	if protocolType != g.protocolType {
+		return false
+	}
+	for _, p := range protocols {
+		if g.allMembersSupport(memberID, p.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *group) allMembersSupport(exceptMemberID string, name string) bool {
+	for id, m := range g.members {
+		if id != exceptMemberID && !m.supportsProtocol(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectProtocol picks the protocol supported by every member that most members prefer.
+func (g *group) selectProtocol() string {
+	votes := make(map[string]int)
+	for _, id := range g.memberIDs() {
+		for _, p := range g.members[id].protocols {
+			if g.allMembersSupport("", p.Name) {
+				votes[p.Name]++
+				break
+			}
+		}
+	}
+	best, bestVotes := "", -1
+	// Iterate the leader's preference order so ties are resolved deterministically.
+	leader := g.members[g.leaderID]
+	for _, p := range leader.protocols {
+		if n, ok := votes[p.Name]; ok && n > bestVotes {
+			best, bestVotes = p.Name, n
+		}
+	}
+	return best
+}
+
+func (g *group) maxRebalanceTimeout() time.Duration {
+	var timeout time.Duration
+	for _, m := range g.members {
+		timeout = max(timeout, m.rebalanceTimeout)
+	}
+	return timeout
+}
+
+func (g *group) allMembersJoined() bool {
+	for _, m := range g.members {
+		if m.awaitingJoin == nil {
+			return false
+		}
+	}
+	return true
+}
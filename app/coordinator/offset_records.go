@@ -0,0 +1,175 @@
+package coordinator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
+	"github.com/codecrafters-io/kafka-starter-go/app/encoder"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// Offset commits are stored in __consumer_offsets with the record layout used
+// by Kafka, so the log stays readable by Kafka tooling.
+//
+// OffsetCommitKey (Version: 1) => group topic partition
+//   group => STRING
+//   topic => STRING
+//   partition => INT32
+//
+// OffsetCommitValue (Version: 3) => offset leader_epoch metadata commit_timestamp
+//   offset => INT64
+//   leader_epoch => INT32
+//   metadata => STRING
+//   commit_timestamp => INT64
+//
+// Both are prefixed with their INT16 version. Key versions 0 and 1 are offset
+// commits, version 2 is group metadata.
+
+const (
+	offsetCommitKeyVersion   int16 = 1
+	offsetCommitValueVersion int16 = 3
+)
+
+type offsetCommitKey struct {
+	Group     string
+	Topic     string
+	Partition int32
+}
+
+func encodeOffsetCommitKey(key offsetCommitKey) ([]byte, error) {
+	var buf bytes.Buffer
+	err := encoder.EncodeValue(&buf, offsetCommitKeyVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode key version: %w", err)
+	}
+	err = encoder.EncodeString(&buf, key.Group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode group: %w", err)
+	}
+	err = encoder.EncodeString(&buf, key.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode topic: %w", err)
+	}
+	err = encoder.EncodeValue(&buf, key.Partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode partition: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeOffsetCommitKey decodes a __consumer_offsets record key. It returns nil
+// without error for keys that are not offset commits.
+func decodeOffsetCommitKey(data []byte) (*offsetCommitKey, error) {
+	r := decoder.NewReader(data)
+	var version int16
+	err := decoder.DecodeValue(r, &version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key version: %w", err)
+	}
+	if version != 0 && version != 1 {
+		return nil, nil
+	}
+	key := &offsetCommitKey{}
+	key.Group, err = decoder.DecodeString(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode group: %w", err)
+	}
+	key.Topic, err = decoder.DecodeString(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode topic: %w", err)
+	}
+	err = decoder.DecodeValue(r, &key.Partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode partition: %w", err)
+	}
+	return key, nil
+}
+
+func encodeOffsetCommitValue(value OffsetAndMetadata) ([]byte, error) {
+	var buf bytes.Buffer
+	err := encoder.EncodeValue(&buf, offsetCommitValueVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value version: %w", err)
+	}
+	err = encoder.EncodeValue(&buf, value.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode offset: %w", err)
+	}
+	err = encoder.EncodeValue(&buf, value.LeaderEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode leader epoch: %w", err)
+	}
+	err = encoder.EncodeString(&buf, value.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	err = encoder.EncodeValue(&buf, value.CommitTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode commit timestamp: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeOffsetCommitValue decodes the value versions 1 and 3 written by Kafka and by this broker.
+func decodeOffsetCommitValue(data []byte) (*OffsetAndMetadata, error) {
+	r := decoder.NewReader(data)
+	var version int16
+	err := decoder.DecodeValue(r, &version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode value version: %w", err)
+	}
+	if version != 1 && version != 3 {
+		return nil, fmt.Errorf("unsupported offset commit value version %d", version)
+	}
+	value := &OffsetAndMetadata{LeaderEpoch: -1}
+	err = decoder.DecodeValue(r, &value.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode offset: %w", err)
+	}
+	if version >= 3 {
+		err = decoder.DecodeValue(r, &value.LeaderEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode leader epoch: %w", err)
+		}
+	}
+	value.Metadata, err = decoder.DecodeString(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	err = decoder.DecodeValue(r, &value.CommitTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode commit timestamp: %w", err)
+	}
+	return value, nil
+}
+
+// encodeOffsetCommitBatch encodes the commits of one group as a single record
+// batch with base offset 0.
+func encodeOffsetCommitBatch(groupID string, offsets map[TopicPartition]OffsetAndMetadata, timestamp int64) ([]byte, error) {
+	partitions := make([]TopicPartition, 0, len(offsets))
+	for tp := range offsets {
+		partitions = append(partitions, tp)
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+
+	records := make([]metadata.Record, len(partitions))
+	for i, tp := range partitions {
+		key, err := encodeOffsetCommitKey(offsetCommitKey{Group: groupID, Topic: tp.Topic, Partition: tp.Partition})
+		if err != nil {
+			return nil, err
+		}
+		value, err := encodeOffsetCommitValue(offsets[tp])
+		if err != nil {
+			return nil, err
+		}
+		records[i] = metadata.Record{Key: key, Value: value}
+	}
+	return metadata.EncodeRecordBatch(metadata.NewRecordBatch(timestamp, records))
+}
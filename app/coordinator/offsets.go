@@ -0,0 +1,192 @@
+package coordinator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// ConsumerOffsetsTopic is the internal topic committed offsets are persisted to.
+// All groups share its single partition.
+const (
+	ConsumerOffsetsTopic     = protocol.ConsumerOffsetsTopic
+	consumerOffsetsPartition = int32(0)
+)
+
+// MaxOffsetMetadataSize is the largest commit metadata string accepted,
+// matching Kafka's offset.metadata.max.bytes default.
+const MaxOffsetMetadataSize = 4096
+
+// TopicPartition identifies a partition of a topic.
+type TopicPartition struct {
+	Topic     string
+	Partition int32
+}
+
+// OffsetAndMetadata is the committed position of a group on a partition.
+type OffsetAndMetadata struct {
+	Offset          int64
+	LeaderEpoch     int32
+	Metadata        string
+	CommitTimestamp int64
+}
+
+// CommitParams holds the fields of an OffsetCommit request. Offsets should only
+// contain partitions the caller has already validated.
+type CommitParams struct {
+	GroupID         string
+	GenerationID    int32
+	MemberID        string
+	GroupInstanceID *string
+	Offsets         map[TopicPartition]OffsetAndMetadata
+}
+
+// CommitOffsets checks that the committer may commit for the group, appends the
+// offsets to the __consumer_offsets log and updates the offset cache. The
+// returned error code applies to every partition of the request.
+func (c *GroupCoordinator) CommitOffsets(params CommitParams) int16 {
+	if params.GroupID == "" {
+		return protocol.ErrorCodeInvalidGroupID
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if errorCode := c.validateOffsetCommit(params); errorCode != protocol.ErrorCodeNone {
+		return errorCode
+	}
+	if len(params.Offsets) == 0 {
+		return protocol.ErrorCodeNone
+	}
+
+	commitTimestamp := time.Now().UnixMilli()
+	for tp, o := range params.Offsets {
+		o.CommitTimestamp = commitTimestamp
+		params.Offsets[tp] = o
+	}
+	batch, err := encodeOffsetCommitBatch(params.GroupID, params.Offsets, commitTimestamp)
+	if err == nil {
+		err = c.appendOffsetsLog(batch)
+	}
+	if err != nil {
+		c.log.Error("failed to persist offset commit", "groupID", params.GroupID, "error", err)
+		return protocol.ErrorCodeKafkaStorageError
+	}
+
+	offsets, ok := c.offsets[params.GroupID]
+	if !ok {
+		offsets = make(map[TopicPartition]OffsetAndMetadata)
+		c.offsets[params.GroupID] = offsets
+	}
+	for tp, o := range params.Offsets {
+		offsets[tp] = o
+	}
+	return protocol.ErrorCodeNone
+}
+
+// validateOffsetCommit mirrors Kafka's classic group checks: members of an
+// active group must commit with their current generation, while commits without
+// a member id (generation -1) are only allowed for groups that have no members.
+func (c *GroupCoordinator) validateOffsetCommit(params CommitParams) int16 {
+	g, ok := c.groups[params.GroupID]
+	if !ok {
+		if params.GenerationID < 0 {
+			return protocol.ErrorCodeNone
+		}
+		return protocol.ErrorCodeIllegalGeneration
+	}
+	if params.GenerationID < 0 && params.MemberID == "" && params.GroupInstanceID == nil {
+		if g.state != Empty {
+			return protocol.ErrorCodeUnknownMemberID
+		}
+		return protocol.ErrorCodeNone
+	}
+	m, ok := g.members[params.MemberID]
+	if !ok {
+		return protocol.ErrorCodeUnknownMemberID
+	}
+	if params.GenerationID != g.generationID {
+		return protocol.ErrorCodeIllegalGeneration
+	}
+	switch g.state {
+	case CompletingRebalance:
+		return protocol.ErrorCodeRebalanceInProgress
+	case Stable:
+		// A commit proves the member is alive, like a heartbeat.
+		c.resetSessionTimer(g, m)
+	}
+	return protocol.ErrorCodeNone
+}
+
+// FetchOffsets returns a copy of the offsets committed by a group.
+func (c *GroupCoordinator) FetchOffsets(groupID string) map[TopicPartition]OffsetAndMetadata {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	offsets := make(map[TopicPartition]OffsetAndMetadata, len(c.offsets[groupID]))
+	for tp, o := range c.offsets[groupID] {
+		offsets[tp] = o
+	}
+	return offsets
+}
+
+// LoadOffsets rebuilds the offset cache by replaying the __consumer_offsets log.
+// It is called once on startup, before the broker accepts connections.
+func (c *GroupCoordinator) LoadOffsets() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	offsetsLog, err := c.logs.Log(ConsumerOffsetsTopic, consumerOffsetsPartition)
+	if err != nil {
+		return fmt.Errorf("failed to open %s log: %w", ConsumerOffsetsTopic, err)
+	}
+	loaded := 0
+	err = offsetsLog.Scan(offsetsLog.LogStartOffset(), false, func(batch metadata.RecordBatch) error {
+		for _, record := range batch.Records {
+			key, err := decodeOffsetCommitKey(record.Key)
+			if err != nil {
+				c.log.Warn("Skipping unreadable offset record key", "offset", batch.BaseOffset+record.OffsetDelta, "error", err)
+				continue
+			}
+			if key == nil {
+				// Not an offset commit (e.g. group metadata), nothing to restore.
+				continue
+			}
+			tp := TopicPartition{Topic: key.Topic, Partition: key.Partition}
+			if record.Value == nil {
+				// Tombstone: the committed offset was deleted.
+				delete(c.offsets[key.Group], tp)
+				continue
+			}
+			value, err := decodeOffsetCommitValue(record.Value)
+			if err != nil {
+				c.log.Warn("Skipping unreadable offset record value", "offset", batch.BaseOffset+record.OffsetDelta, "error", err)
+				continue
+			}
+			offsets, ok := c.offsets[key.Group]
+			if !ok {
+				offsets = make(map[TopicPartition]OffsetAndMetadata)
+				c.offsets[key.Group] = offsets
+			}
+			offsets[tp] = *value
+			loaded++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s log: %w", ConsumerOffsetsTopic, err)
+	}
+	c.log.Info("Loaded committed offsets", "records", loaded, "groups", len(c.offsets))
+	return nil
+}
+
+func (c *GroupCoordinator) appendOffsetsLog(batch []byte) error {
+	offsetsLog, err := c.logs.Log(ConsumerOffsetsTopic, consumerOffsetsPartition)
+	if err != nil {
+		return err
+	}
+	_, err = offsetsLog.Append(batch)
+	return err
+}
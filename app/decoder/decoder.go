@@ -2,14 +2,52 @@ package decoder
 
 import (
 	"bufio"
+	"bytes"
+	"cmp"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
-	"strconv"
+	"slices"
 
 	"github.com/google/uuid"
 )
 
+// ErrInvalidLength is returned for a length, size or count read from the input
+// that is negative or exceeds the bytes left in it.
+var ErrInvalidLength = errors.New("invalid length")
+
+// NewReader returns a reader over data that holds all of data in its buffer, so
+// that its Buffered method reports the bytes left. Lengths read from the input
+// are checked against them before anything of that length is allocated, so
+// the decoders must be given readers created by NewReader.
+func NewReader(data []byte) *bufio.Reader {
+	r := bufio.NewReaderSize(bytes.NewReader(data), len(data))
+	r.Peek(len(data))
+	return r
+}
+
+// checkLength returns ErrInvalidLength if length exceeds the bytes left in r.
+func checkLength(r *bufio.Reader, length uint64) error {
+	if length > uint64(r.Buffered()) {
+		return fmt.Errorf("%w: %d exceeds the %d bytes left", ErrInvalidLength, length, r.Buffered())
+	}
+	return nil
+}
+
+// DecodeRawBytes reads the next length bytes of r, failing with
+// ErrInvalidLength instead of allocating them if fewer are left.
+func DecodeRawBytes(r *bufio.Reader, length uint64) ([]byte, error) {
+	if err := checkLength(r, length); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
 func DecodeCompactString(r *bufio.Reader) (string, error) {
 	length, err := DecodeUvarint(r) // Assumes DecodeUvarint is in this package or imported
 	if err != nil {
@@ -23,9 +61,8 @@ func DecodeCompactString(r *bufio.Reader) (string, error) {
 	if length < 1 { // Should not happen if 0 is null and others are length+1
 		return "", fmt.Errorf("invalid compact string length: %d", length)
 	}
-	actualLength := length - 1
-	buf := make([]byte, actualLength)
-	if _, err := io.ReadFull(r, buf); err != nil {
+	buf, err := DecodeRawBytes(r, length-1)
+	if err != nil {
 		return "", fmt.Errorf("failed to read compact string bytes: %w", err)
 	}
 	return string(buf), nil
@@ -40,10 +77,10 @@ func DecodeSpecialBytes(r *bufio.Reader) ([]byte, error) {
 		return nil, nil // Empty bytes
 	}
 	if length < 0 {
-		return nil, fmt.Errorf("invalid length: %d", length)
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLength, length)
 	}
-	buf := make([]byte, length)
-	if _, err := io.ReadFull(r, buf); err != nil {
+	buf, err := DecodeRawBytes(r, uint64(length))
+	if err != nil {
 		return nil, fmt.Errorf("failed to read bytes: %w", err)
 	}
 	return buf, nil
@@ -59,8 +96,7 @@ func DecodeVarint(r *bufio.Reader) (int64, error) {
 
 func DecodeUUID(r *bufio.Reader) (uuid.UUID, error) {
 	var value uuid.UUID
-	err := binary.Read(r, binary.BigEndian, &value)
-	if err != nil {
+	if _, err := io.ReadFull(r, value[:]); err != nil {
 		return uuid.Nil, fmt.Errorf("failed to decode uuid: %w", err)
 	}
 	return value, nil
@@ -82,17 +118,76 @@ func DecodeCompactArrayLength(r *bufio.Reader) (int, error) {
 	if length == 0 {
 		return 0, fmt.Errorf("compact array length is 0")
 	}
+	// Every element takes at least one byte.
+	if err := checkLength(r, length-1); err != nil {
+		return 0, fmt.Errorf("failed to decode compact array length: %w", err)
+	}
 	return int(length - 1), nil
 }
 
-func DecodeEmptyTaggedField(r *bufio.Reader) {
-	tag, err := DecodeUvarint(r) // Assumes DecodeUvarint is in this package
+// TaggedField is a tagged field kept as its raw bytes.
+type TaggedField struct {
+	Tag  uint64
+	Data []byte
+}
+
+// TaggedFields is the tagged field section of a flexible message, ordered by
+// tag. Message types take out the tags they know; the others are kept so that
+// they can be encoded again.
+type TaggedFields []TaggedField
+
+// DecodeTaggedFields decodes a tagged field section: a field count followed by
+// the tag, size and data of every field.
+func DecodeTaggedFields(r *bufio.Reader) (TaggedFields, error) {
+	count, err := DecodeUvarint(r)
 	if err != nil {
-		panic("failed to decode tag: " + err.Error())
+		return nil, fmt.Errorf("failed to decode tagged fields count: %w", err)
+	}
+	// Every field takes at least two bytes, its tag and its size.
+	if err := checkLength(r, count); err != nil {
+		return nil, fmt.Errorf("failed to decode tagged fields count: %w", err)
 	}
-	if tag != 0 {
-		panic("tag is not 0: " + strconv.FormatUint(tag, 10))
+	var fields TaggedFields
+	for range count {
+		tag, err := DecodeUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tag: %w", err)
+		}
+		size, err := DecodeUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode size of tagged field %d: %w", tag, err)
+		}
+		data, err := DecodeRawBytes(r, size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tagged field %d: %w", tag, err)
+		}
+		fields = append(fields, TaggedField{Tag: tag, Data: data})
 	}
+	return fields, nil
+}
+
+// Take removes the field with the given tag and returns a reader over its data.
+func (f *TaggedFields) Take(tag uint64) (*bufio.Reader, bool) {
+	for i, field := range *f {
+		if field.Tag == tag {
+			*f = slices.Delete(*f, i, i+1)
+			return NewReader(field.Data), true
+		}
+	}
+	return nil, false
+}
+
+// Set adds the field with the given tag, replacing any field with the same tag
+// and keeping the fields ordered by tag.
+func (f *TaggedFields) Set(tag uint64, data []byte) {
+	i, found := slices.BinarySearchFunc(*f, tag, func(field TaggedField, tag uint64) int {
+		return cmp.Compare(field.Tag, tag)
+	})
+	if found {
+		(*f)[i].Data = data
+		return
+	}
+	*f = slices.Insert(*f, i, TaggedField{Tag: tag, Data: data})
 }
 
 func PeekNextByte(r *bufio.Reader) (byte, error) {
@@ -104,3 +199,176 @@ func PeekNextByte(r *bufio.Reader) (byte, error) {
 	r.UnreadByte()
 	return buf[0], nil
 }
+
+// DecodeString decodes a non-nullable STRING (int16 length prefix).
+func DecodeString(r *bufio.Reader) (string, error) {
+	s, err := DecodeNullableString(r)
+	if err != nil {
+		return "", err
+	}
+	if s == nil {
+		return "", nil
+	}
+	return *s, nil
+}
+
+// DecodeNullableString decodes a NULLABLE_STRING. A length of -1 is returned as nil.
+func DecodeNullableString(r *bufio.Reader) (*string, error) {
+	length, err := DecodeInt16(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode string length: %w", err)
+	}
+	if length == -1 {
+		return nil, nil
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("failed to decode string length: %w: %d", ErrInvalidLength, length)
+	}
+	buf, err := DecodeRawBytes(r, uint64(length))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read string bytes: %w", err)
+	}
+	s := string(buf)
+	return &s, nil
+}
+
+// DecodeCompactNullableString decodes a COMPACT_NULLABLE_STRING. A length of 0 is returned as nil.
+func DecodeCompactNullableString(r *bufio.Reader) (*string, error) {
+	length, err := DecodeUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode compact nullable string length: %w", err)
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	buf, err := DecodeRawBytes(r, length-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compact nullable string bytes: %w", err)
+	}
+	s := string(buf)
+	return &s, nil
+}
+
+// DecodeBytes decodes NULLABLE_BYTES (int32 length prefix). A length of -1 is returned as nil.
+func DecodeBytes(r *bufio.Reader) ([]byte, error) {
+	length, err := DecodeInt32(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bytes length: %w", err)
+	}
+	if length == -1 {
+		return nil, nil
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("failed to decode bytes length: %w: %d", ErrInvalidLength, length)
+	}
+	buf, err := DecodeRawBytes(r, uint64(length))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bytes: %w", err)
+	}
+	return buf, nil
+}
+
+// DecodeCompactBytes decodes COMPACT_NULLABLE_BYTES. A length of 0 is returned as nil.
+func DecodeCompactBytes(r *bufio.Reader) ([]byte, error) {
+	length, err := DecodeUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode compact bytes length: %w", err)
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	buf, err := DecodeRawBytes(r, length-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compact bytes: %w", err)
+	}
+	return buf, nil
+}
+
+// DecodeArrayLength decodes the int32 length of a non-compact ARRAY. A null array is returned as -1.
+func DecodeArrayLength(r *bufio.Reader) (int, error) {
+	length, err := DecodeInt32(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode array length: %w", err)
+	}
+	if length == -1 {
+		return -1, nil
+	}
+	if length < 0 {
+		return 0, fmt.Errorf("failed to decode array length: %w: %d", ErrInvalidLength, length)
+	}
+	// Every element takes at least one byte.
+	if err := checkLength(r, uint64(length)); err != nil {
+		return 0, fmt.Errorf("failed to decode array length: %w", err)
+	}
+	return int(length), nil
+}
+
+// The DecodeFlex* helpers pick between the classic and the compact encoding
+// depending on whether the message version is a flexible version.
+
+func DecodeFlexString(r *bufio.Reader, flexible bool) (string, error) {
+	if flexible {
+		return DecodeCompactString(r)
+	}
+	return DecodeString(r)
+}
+
+func DecodeFlexNullableString(r *bufio.Reader, flexible bool) (*string, error) {
+	if flexible {
+		return DecodeCompactNullableString(r)
+	}
+	return DecodeNullableString(r)
+}
+
+func DecodeFlexBytes(r *bufio.Reader, flexible bool) ([]byte, error) {
+	if flexible {
+		return DecodeCompactBytes(r)
+	}
+	return DecodeBytes(r)
+}
+
+// DecodeFlexArrayLength returns the number of elements of an array, or -1 for a null array.
+func DecodeFlexArrayLength(r *bufio.Reader, flexible bool) (int, error) {
+	if !flexible {
+		return DecodeArrayLength(r)
+	}
+	length, err := DecodeUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode compact array length: %w", err)
+	}
+	if length == 0 {
+		return -1, nil
+	}
+	if err := checkLength(r, length-1); err != nil {
+		return 0, fmt.Errorf("failed to decode compact array length: %w", err)
+	}
+	return int(length - 1), nil
+}
+
+// DecodeFlexTaggedFields decodes the tagged field section of flexible
+// versions. Older versions have none.
+func DecodeFlexTaggedFields(r *bufio.Reader, flexible bool) (TaggedFields, error) {
+	if !flexible {
+		return nil, nil
+	}
+	return DecodeTaggedFields(r)
+}
+
+// DecodeInt32Array decodes an array of int32 values. A null array is returned as nil.
+func DecodeInt32Array(r *bufio.Reader, flexible bool) ([]int32, error) {
+	length, err := DecodeFlexArrayLength(r, flexible)
+	if err != nil {
+		return nil, err
+	}
+	if length < 0 {
+		return nil, nil
+	}
+	arr := make([]int32, length)
+	for i := range arr {
+		arr[i], err = DecodeInt32(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode int32 array item: %w", err)
+		}
+	}
+	return arr, nil
+}
@@ -0,0 +1,63 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// The typed decoders below read the fixed-size big-endian primitives of the
+// Kafka protocol without going through reflection like DecodeValue.
+
+// DecodeBool decodes a boolean; any non-zero byte is true.
+func DecodeBool(r io.Reader) (bool, error) {
+	v, err := DecodeInt8(r)
+	return v != 0, err
+}
+
+func DecodeInt8(r io.Reader) (int8, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return int8(b[0]), nil
+}
+
+func DecodeInt16(r io.Reader) (int16, error) {
+	v, err := DecodeUint16(r)
+	return int16(v), err
+}
+
+func DecodeUint16(r io.Reader) (uint16, error) {
+	var b [2]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b[:]), nil
+}
+
+func DecodeInt32(r io.Reader) (int32, error) {
+	v, err := DecodeUint32(r)
+	return int32(v), err
+}
+
+func DecodeUint32(r io.Reader) (uint32, error) {
+	var b [4]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b[:]), nil
+}
+
+func DecodeInt64(r io.Reader) (int64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
+
+func DecodeFloat64(r io.Reader) (float64, error) {
+	v, err := DecodeInt64(r)
+	return math.Float64frombits(uint64(v)), err
+}
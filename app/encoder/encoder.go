@@ -4,10 +4,12 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
 )
 
 func EncodeTaggedField(w io.Writer) error {
-	return binary.Write(w, binary.BigEndian, int8(0))
+	return EncodeInt8(w, 0)
 }
 
 func EncodeCompactString(w io.Writer, s string) error {
@@ -70,7 +72,7 @@ func EncodeUvarint(w io.Writer, value uint64) error {
 }
 
 func EncodeVarint(w io.Writer, value int64) error {
-	buf := make([]byte, binary.MaxVarintLen32)
+	buf := make([]byte, binary.MaxVarintLen64)
 	n := binary.PutVarint(buf, value)
 	_, err := w.Write(buf[:n])
 	return err
@@ -88,7 +90,148 @@ func EncodeInt32Array(w io.Writer, arr []int32) error {
 		return fmt.Errorf("failed to encode int32 array length: %w", err)
 	}
 	for _, item := range arr {
-		err = EncodeValue(w, item) // EncodeValue handles int32
+		err = EncodeInt32(w, item)
+		if err != nil {
+			return fmt.Errorf("failed to encode int32 array item: %w", err)
+		}
+	}
+	return nil
+}
+
+// EncodeString encodes a non-nullable STRING (int16 length prefix).
+func EncodeString(w io.Writer, s string) error {
+	err := EncodeInt16(w, int16(len(s)))
+	if err != nil {
+		return fmt.Errorf("failed to encode string length: %w", err)
+	}
+	_, err = w.Write([]byte(s))
+	if err != nil {
+		return fmt.Errorf("failed to encode string: %w", err)
+	}
+	return nil
+}
+
+// EncodeNullableString encodes a NULLABLE_STRING. A nil string is encoded as length -1.
+func EncodeNullableString(w io.Writer, s *string) error {
+	if s == nil {
+		return EncodeInt16(w, -1)
+	}
+	return EncodeString(w, *s)
+}
+
+// EncodeBytes encodes NULLABLE_BYTES (int32 length prefix). A nil slice is encoded as length -1.
+func EncodeBytes(w io.Writer, b []byte) error {
+	if b == nil {
+		return EncodeInt32(w, -1)
+	}
+	err := EncodeInt32(w, int32(len(b)))
+	if err != nil {
+		return fmt.Errorf("failed to encode bytes length: %w", err)
+	}
+	_, err = w.Write(b)
+	if err != nil {
+		return fmt.Errorf("failed to encode bytes: %w", err)
+	}
+	return nil
+}
+
+// EncodeCompactBytes encodes COMPACT_NULLABLE_BYTES. A nil slice is encoded as Uvarint 0 length.
+func EncodeCompactBytes(w io.Writer, b []byte) error {
+	if b == nil {
+		return EncodeUvarint(w, 0)
+	}
+	err := EncodeUvarint(w, uint64(len(b)+1))
+	if err != nil {
+		return fmt.Errorf("failed to encode compact bytes length: %w", err)
+	}
+	_, err = w.Write(b)
+	if err != nil {
+		return fmt.Errorf("failed to encode compact bytes: %w", err)
+	}
+	return nil
+}
+
+// EncodeArrayLength encodes the int32 length of a non-compact ARRAY. A negative length encodes a null array.
+func EncodeArrayLength(w io.Writer, length int) error {
+	if length < 0 {
+		return EncodeInt32(w, -1)
+	}
+	return EncodeInt32(w, int32(length))
+}
+
+// The EncodeFlex* helpers pick between the classic and the compact encoding
+// depending on whether the message version is a flexible version.
+
+func EncodeFlexString(w io.Writer, s string, flexible bool) error {
+	if flexible {
+		return EncodeCompactString(w, s)
+	}
+	return EncodeString(w, s)
+}
+
+func EncodeFlexNullableString(w io.Writer, s *string, flexible bool) error {
+	if flexible {
+		return EncodeCompactNullableString(w, s)
+	}
+	return EncodeNullableString(w, s)
+}
+
+func EncodeFlexBytes(w io.Writer, b []byte, flexible bool) error {
+	if flexible {
+		return EncodeCompactBytes(w, b)
+	}
+	return EncodeBytes(w, b)
+}
+
+// EncodeFlexArrayLength encodes an array length. A negative length encodes a null array.
+func EncodeFlexArrayLength(w io.Writer, length int, flexible bool) error {
+	if !flexible {
+		return EncodeArrayLength(w, length)
+	}
+	if length < 0 {
+		return EncodeUvarint(w, 0)
+	}
+	return EncodeCompactArrayLength(w, length)
+}
+
+func EncodeFlexTaggedFields(w io.Writer, flexible bool) error {
+	if flexible {
+		return EncodeTaggedField(w)
+	}
+	return nil
+}
+
+// EncodeTaggedFields writes a tagged field section. The fields must be ordered by tag.
+func EncodeTaggedFields(w io.Writer, fields decoder.TaggedFields) error {
+	err := EncodeUvarint(w, uint64(len(fields)))
+	if err != nil {
+		return fmt.Errorf("failed to encode tagged fields count: %w", err)
+	}
+	for _, field := range fields {
+		err = EncodeUvarint(w, field.Tag)
+		if err != nil {
+			return fmt.Errorf("failed to encode tag: %w", err)
+		}
+		err = EncodeUvarint(w, uint64(len(field.Data)))
+		if err != nil {
+			return fmt.Errorf("failed to encode size of tagged field %d: %w", field.Tag, err)
+		}
+		_, err = w.Write(field.Data)
+		if err != nil {
+			return fmt.Errorf("failed to encode tagged field %d: %w", field.Tag, err)
+		}
+	}
+	return nil
+}
+
+// EncodeFlexInt32Array encodes an array of int32 values. A nil slice is encoded as an empty array.
+func EncodeFlexInt32Array(w io.Writer, arr []int32, flexible bool) error {
+	err := EncodeFlexArrayLength(w, len(arr), flexible)
+	if err != nil {
+		return fmt.Errorf("failed to encode int32 array length: %w", err)
+	}
+	for _, item := range arr {
+		err = EncodeInt32(w, item)
 		if err != nil {
 			return fmt.Errorf("failed to encode int32 array item: %w", err)
 		}
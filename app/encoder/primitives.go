@@ -0,0 +1,63 @@
+package encoder
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// The typed encoders below write the fixed-size big-endian primitives of the
+// Kafka protocol without going through reflection like EncodeValue.
+
+// EncodeBool encodes a boolean as 1 or 0.
+func EncodeBool(w io.Writer, v bool) error {
+	if v {
+		return EncodeInt8(w, 1)
+	}
+	return EncodeInt8(w, 0)
+}
+
+func EncodeInt8(w io.Writer, v int8) error {
+	_, err := w.Write([]byte{byte(v)})
+	return err
+}
+
+func EncodeInt16(w io.Writer, v int16) error {
+	return EncodeUint16(w, uint16(v))
+}
+
+func EncodeUint16(w io.Writer, v uint16) error {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], v)
+	_, err := w.Write(b[:])
+	return err
+}
+
+func EncodeInt32(w io.Writer, v int32) error {
+	return EncodeUint32(w, uint32(v))
+}
+
+func EncodeUint32(w io.Writer, v uint32) error {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	_, err := w.Write(b[:])
+	return err
+}
+
+func EncodeInt64(w io.Writer, v int64) error {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	_, err := w.Write(b[:])
+	return err
+}
+
+func EncodeFloat64(w io.Writer, v float64) error {
+	return EncodeInt64(w, int64(math.Float64bits(v)))
+}
+
+func EncodeUUID(w io.Writer, v uuid.UUID) error {
+	_, err := w.Write(v[:])
+	return err
+}
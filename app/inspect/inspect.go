@@ -0,0 +1,115 @@
+// Package inspect traces the records produced to and fetched from topics. It
+// is meant for debugging and is off unless topics are opted in.
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// Source tells where inspected records come from.
+type Source string
+
+const (
+	Produce Source = "produce"
+	Fetch   Source = "fetch"
+)
+
+// AllTopics in Config.Topics dumps the records of every topic.
+const AllTopics = "*"
+
+// Inspector is told about the record batches appended to or served from a
+// partition. records holds complete raw record batches. Implementations must
+// be safe for concurrent use and must not modify records.
+type Inspector interface {
+	Inspect(log *slog.Logger, source Source, topic string, partition int32, records []byte)
+}
+
+// Nop is the default Inspector, which ignores all records.
+var Nop Inspector = nop{}
+
+type nop struct{}
+
+func (nop) Inspect(*slog.Logger, Source, string, int32, []byte) {}
+
+// Config selects what a dump Inspector logs.
+type Config struct {
+	// Topics are the topics whose records are dumped, or AllTopics.
+	Topics []string
+	// MaxBytes is how many bytes of keys, values and header values are shown; 0 shows all of them.
+	MaxBytes int
+	// RedactKeys, RedactValues and RedactHeaders replace the record keys, values
+	// or header values with their size.
+	RedactKeys    bool
+	RedactValues  bool
+	RedactHeaders bool
+}
+
+// New returns an Inspector that logs every record of the configured topics,
+// or Nop if no topics are configured.
+func New(cfg Config) Inspector {
+	if len(cfg.Topics) == 0 {
+		return Nop
+	}
+	d := &dumper{cfg: cfg, topics: make(map[string]bool, len(cfg.Topics))}
+	for _, topic := range cfg.Topics {
+		if topic == AllTopics {
+			d.all = true
+		}
+		d.topics[topic] = true
+	}
+	return d
+}
+
+// dumper logs the records of the selected topics at info level.
+type dumper struct {
+	cfg    Config
+	all    bool
+	topics map[string]bool
+}
+
+func (d *dumper) Inspect(log *slog.Logger, source Source, topic string, partition int32, records []byte) {
+	if !d.all && !d.topics[topic] {
+		return
+	}
+	rd := decoder.NewReader(records)
+	for {
+		batch, err := metadata.DecodeRecordBatch(rd, false, metadata.MaxStoredRecordsSize)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Warn("failed to decode inspected record batch", "source", source, "topic", topic, "partition", partition, "error", err)
+			return
+		}
+		for _, record := range batch.Records {
+			headers := make([]string, len(record.Headers))
+			for i, header := range record.Headers {
+				headers[i] = header.Key + "=" + d.format(header.Value, d.cfg.RedactHeaders)
+			}
+			log.Info("Record", "source", source, "topic", topic, "partition", partition,
+				"offset", batch.BaseOffset+record.OffsetDelta, "timestamp", batch.FirstTimestamp+record.TimestampDelta,
+				"key", d.format(record.Key, d.cfg.RedactKeys), "value", d.format(record.Value, d.cfg.RedactValues),
+				"headers", headers)
+		}
+	}
+}
+
+// format renders b for the log, truncated to MaxBytes or redacted.
+func (d *dumper) format(b []byte, redact bool) string {
+	switch {
+	case b == nil:
+		return "null"
+	case redact:
+		return fmt.Sprintf("<redacted %d bytes>", len(b))
+	case d.cfg.MaxBytes > 0 && len(b) > d.cfg.MaxBytes:
+		return fmt.Sprintf("%q...(%d more bytes)", b[:d.cfg.MaxBytes], len(b)-d.cfg.MaxBytes)
+	default:
+		return fmt.Sprintf("%q", b)
+	}
+}
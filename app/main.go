@@ -4,15 +4,33 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 
 	"github.com/codecrafters-io/kafka-starter-go/app/config"
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/inspect"
 	"github.com/codecrafters-io/kafka-starter-go/app/logger"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol/apiversions"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/createtopics"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/deletetopics"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol/describetopic"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol/fetch"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/findcoordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/heartbeat"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/joingroup"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/leavegroup"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/listoffsets"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/offsetcommit"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/offsetfetch"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/produce"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/syncgroup"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/topicmetadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/purgatory"
 	"github.com/codecrafters-io/kafka-starter-go/app/server"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
 )
 
 func main() {
@@ -27,18 +45,85 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Partition logs spread across the configured log directories
+	logs, err := storage.NewLogManager(cfg.LogDirs, storage.Config{
+		SegmentBytes:       cfg.LogSegmentBytes,
+		SegmentMs:          cfg.LogRollMs,
+		IndexIntervalBytes: cfg.LogIndexIntervalBytes,
+	})
+	if err != nil {
+		log.Error("Failed to open log directories", "error", err)
+		os.Exit(1)
+	}
+	defer logs.Close()
+	metadataLog, err := logs.MetadataLog()
+	if err != nil {
+		log.Error("Failed to open cluster metadata log", "error", err)
+		os.Exit(1)
+	}
+
+	// Cluster metadata image shared by the handlers, loaded once from the metadata log
+	images, err := metadataimage.Load(log, metadataLog)
+	if err != nil {
+		log.Error("Failed to load cluster metadata", "error", err)
+		os.Exit(1)
+	}
+
+	// Consumer group coordination state shared by the group handlers
+	groupCoordinator := coordinator.NewGroupCoordinator(log, logs)
+	if err := groupCoordinator.LoadOffsets(); err != nil {
+		log.Error("Failed to load committed offsets", "error", err)
+		os.Exit(1)
+	}
+
+	// Fetches waiting for produced records
+	fetches := purgatory.New()
+
+	// Debug dump of the records of selected topics, off by default
+	inspector := inspect.New(inspect.Config{
+		Topics:        cfg.RecordDumpTopics,
+		MaxBytes:      cfg.RecordDumpMaxBytes,
+		RedactKeys:    slices.Contains(cfg.RecordDumpRedact, "keys"),
+		RedactValues:  slices.Contains(cfg.RecordDumpRedact, "values"),
+		RedactHeaders: slices.Contains(cfg.RecordDumpRedact, "headers"),
+	})
+
 	// Instantiate handlers
-	apiVersionsHandler := apiversions.NewApiVersionsHandler()
-	describeTopicHandler := describetopic.NewDescribeTopicHandler()
-	fetchHandler := fetch.NewFetchHandler()
+	describeTopicHandler := describetopic.NewDescribeTopicHandler(images)
+	fetchHandler := fetch.NewFetchHandler(images, logs, fetches, inspector)
+	produceHandler := produce.NewProduceHandler(images, logs, fetches, inspector, cfg.MessageMaxBytes)
+	metadataHandler := topicmetadata.NewMetadataHandler(cfg, images)
+	listOffsetsHandler := listoffsets.NewListOffsetsHandler(images, logs)
+	findCoordinatorHandler := findcoordinator.NewFindCoordinatorHandler(cfg)
+	joinGroupHandler := joingroup.NewJoinGroupHandler(groupCoordinator)
+	syncGroupHandler := syncgroup.NewSyncGroupHandler(groupCoordinator)
+	heartbeatHandler := heartbeat.NewHeartbeatHandler(groupCoordinator)
+	leaveGroupHandler := leavegroup.NewLeaveGroupHandler(groupCoordinator)
+	offsetCommitHandler := offsetcommit.NewOffsetCommitHandler(groupCoordinator, images)
+	offsetFetchHandler := offsetfetch.NewOffsetFetchHandler(groupCoordinator)
+	createTopicsHandler := createtopics.NewCreateTopicsHandler(cfg, images, logs)
+	deleteTopicsHandler := deletetopics.NewDeleteTopicsHandler(images, logs)
 
 	// Collect handlers
 	handlers := []protocol.RequestHandler{
-		apiVersionsHandler,
 		describeTopicHandler,
 		fetchHandler,
+		produceHandler,
+		metadataHandler,
+		listOffsetsHandler,
+		findCoordinatorHandler,
+		joinGroupHandler,
+		syncGroupHandler,
+		heartbeatHandler,
+		leaveGroupHandler,
+		offsetCommitHandler,
+		offsetFetchHandler,
+		createTopicsHandler,
+		deleteTopicsHandler,
 		// Add other handlers here as they are created
 	}
+	// ApiVersions advertises the version ranges declared by the other handlers
+	handlers = append(handlers, apiversions.NewApiVersionsHandler(images, handlers))
 
 	// Create and start server, passing the handlers
 	srv := server.New(cfg, log, handlers) // Pass the configured logger and handlers
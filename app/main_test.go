@@ -1,11 +1,44 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"log/slog"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/codecrafters-io/kafka-starter-go/app/compression"
+	"github.com/codecrafters-io/kafka-starter-go/app/config"
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
+	"github.com/codecrafters-io/kafka-starter-go/app/inspect"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/apiversions"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/createtopics"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/deletetopics"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/fetch"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/heartbeat"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/joingroup"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/leavegroup"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/produce"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/syncgroup"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/topicmetadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/purgatory"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+	"github.com/google/uuid"
 )
 
 func TestDecodeCompactString(t *testing.T) {
@@ -64,3 +97,1393 @@ func TestDecodeTopicLog(t *testing.T) {
 	}
 	t.Log(clusterMetadata2)
 }
+
+func TestRecordBatchCRC(t *testing.T) {
+	data := []byte{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 68, 0, 0, 0, 0, 2, 100, 97, 124, 74, 0, 0, 0, 0, 0, 0, 0, 0, 1, 145, 224, 91, 109, 139, 0, 0, 1, 145, 224, 91, 109, 139, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 36, 0, 0, 0, 1, 24, 72, 101, 108, 108, 111, 32, 69, 97, 114, 116, 104, 33, 0,
+	}
+	corrupt := bytes.Clone(data)
+	corrupt[len(corrupt)-2] ^= 0xff
+	_, err := protocol.DecodeClusterMetadata(corrupt, false)
+	if !errors.Is(err, metadata.ErrCorruptBatch) {
+		t.Fatalf("decoding a corrupt batch returned %v, want ErrCorruptBatch", err)
+	}
+
+	clusterMetadata, err := protocol.DecodeClusterMetadata(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordBatch := clusterMetadata.RecordBatchs[0]
+	recordBatch.Records[0].Value = []byte("Hello Earth")
+	buf := bytes.NewBuffer(nil)
+	err = recordBatch.Encode(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := metadata.VerifyBatchCRC(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if int(recordBatch.BatchLength) != buf.Len()-12 {
+		t.Fatalf("batch length %d does not match %d encoded bytes", recordBatch.BatchLength, buf.Len())
+	}
+	if _, err := protocol.DecodeClusterMetadata(buf.Bytes(), false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecodeMetadataRecordTypes(t *testing.T) {
+	dir := uuid.New()
+	broker := &messages.RegisterBrokerRecord{BrokerId: 1, BrokerEpoch: 7, Rack: nil, Fenced: true, LogDirs: []uuid.UUID{dir}}
+	partition := &messages.PartitionRecord{Replicas: []int32{1}, Isr: []int32{1}, Directories: []uuid.UUID{dir},
+		EligibleLeaderReplicas: []int32{2}, LastKnownElr: []int32{}}
+	var records []metadata.Record
+	for _, record := range []metadata.MetadataRecord{
+		&metadata.GeneratedRecord{Type: metadata.RecordTypeRegisterBroker, RecordVersion: 3, Message: broker},
+		&metadata.GeneratedRecord{Type: metadata.RecordTypePartition, RecordVersion: 2, Message: partition},
+		&metadata.GeneratedRecord{Type: metadata.RecordTypeNoOp, Message: &messages.NoOpRecord{}},
+	} {
+		value, err := metadata.EncodeMetadataRecordValue(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, metadata.Record{Value: value})
+	}
+	// A record type or version this broker does not know is skipped.
+	records = append(records, metadata.Record{Value: []byte{1, 100, 0, 42}}, metadata.Record{Value: []byte{1, 2, 9, 42}})
+	data, err := metadata.EncodeRecordBatch(metadata.NewRecordBatch(1726045943832, records))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterMetadata, err := protocol.DecodeClusterMetadata(data, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := clusterMetadata.RecordBatchs[0].Records
+	if r, ok := got[0].ValueEncodedRecord.(*messages.RegisterBrokerRecord); !ok || r.BrokerEpoch != 7 || len(r.LogDirs) != 1 || r.LogDirs[0] != dir {
+		t.Fatalf("decoded RegisterBrokerRecord as %#v", got[0].ValueEncodedRecord)
+	}
+	if r, ok := got[1].ValueEncodedRecord.(*messages.PartitionRecord); !ok || got[1].ValueEncodedBaseRecode.Version != 2 ||
+		len(r.EligibleLeaderReplicas) != 1 || r.EligibleLeaderReplicas[0] != 2 || r.LastKnownElr == nil || len(r.LastKnownElr) != 0 {
+		t.Fatalf("decoded PartitionRecord as %#v", got[1].ValueEncodedRecord)
+	}
+	if _, ok := got[2].ValueEncodedRecord.(*messages.NoOpRecord); !ok {
+		t.Fatalf("decoded NoOpRecord as %#v", got[2].ValueEncodedRecord)
+	}
+	if got[3].ValueEncodedRecord != nil || got[3].ValueEncodedRecordType != 100 {
+		t.Fatalf("decoded unknown record type as %d %#v", got[3].ValueEncodedRecordType, got[3].ValueEncodedRecord)
+	}
+	if got[4].ValueEncodedRecord != nil || got[4].ValueEncodedRecordType != metadata.RecordTypeTopic {
+		t.Fatalf("decoded unknown TopicRecord version as %d %#v", got[4].ValueEncodedRecordType, got[4].ValueEncodedRecord)
+	}
+}
+
+func TestRecoveryKeepsCorruptBatch(t *testing.T) {
+	dir := t.TempDir()
+	cfg := storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: 4096}
+	partitionLog, err := storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var first int
+	for i, value := range []string{"first", "second"} {
+		batch, err := metadata.EncodeRecordBatch(metadata.NewRecordBatch(1726045943832, []metadata.Record{{Value: []byte(value)}}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			first = len(batch)
+		}
+		if _, err := partitionLog.Append(batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	partitionLog.Close()
+
+	segmentPath := filepath.Join(dir, "00000000000000000000.log")
+	data, err := os.ReadFile(segmentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[first-2] ^= 0xff
+	if err := os.WriteFile(segmentPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	partitionLog, err = storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer partitionLog.Close()
+	if end := partitionLog.LogEndOffset(); end != 2 {
+		t.Fatalf("log end offset after recovery is %d, want 2", end)
+	}
+	if _, err := partitionLog.Read(0, 1<<20, true); !errors.Is(err, metadata.ErrCorruptBatch) {
+		t.Fatalf("reading the corrupt batch returned %v, want ErrCorruptBatch", err)
+	}
+	if _, err := partitionLog.Read(1, 1<<20, true); err != nil {
+		t.Fatalf("reading the batch after the corrupt one: %v", err)
+	}
+}
+
+// appendValue appends a batch holding a single record with the given timestamp
+// and returns its offset.
+func appendValue(t *testing.T, partitionLog *storage.Log, timestamp int64, value string) int64 {
+	t.Helper()
+	batch, err := metadata.EncodeRecordBatch(metadata.NewRecordBatch(timestamp, []metadata.Record{{Value: []byte(value)}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offset, err := partitionLog.Append(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return offset
+}
+
+// readBaseOffset reads from offset and returns the base offset of the first batch read.
+func readBaseOffset(t *testing.T, partitionLog *storage.Log, offset int64) int64 {
+	t.Helper()
+	data, err := partitionLog.Read(offset, 1, true)
+	if err != nil {
+		t.Fatalf("reading offset %d: %v", offset, err)
+	}
+	if len(data) < 8 {
+		t.Fatalf("reading offset %d returned %d bytes", offset, len(data))
+	}
+	return int64(binary.BigEndian.Uint64(data))
+}
+
+// segmentFiles returns the names of the files in dir with the given suffix.
+func segmentFiles(t *testing.T, dir, suffix string) []string {
+	t.Helper()
+	names, err := filepath.Glob(filepath.Join(dir, "*"+suffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+func TestSegmentRoll(t *testing.T) {
+	const t0 = 1726045943832
+	dir := t.TempDir()
+	cfg := storage.Config{SegmentBytes: 300, SegmentMs: time.Hour, IndexIntervalBytes: 4096}
+	partitionLog, err := storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 10 {
+		appendValue(t, partitionLog, t0+int64(i), fmt.Sprintf("value %d", i))
+	}
+	if logs := segmentFiles(t, dir, ".log"); len(logs) < 3 {
+		t.Fatalf("10 batches in segments of 300 bytes were written to %d segments", len(logs))
+	}
+	partitionLog.Close()
+
+	// The rolled segments are loaded from their indexes, the active one is recovered.
+	partitionLog, err = storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start, end := partitionLog.LogStartOffset(), partitionLog.LogEndOffset(); start != 0 || end != 10 {
+		t.Fatalf("reopened log spans offsets %d to %d, want 0 to 10", start, end)
+	}
+	if timestamp, offset := partitionLog.MaxTimestamp(); timestamp != t0+9 || offset != 9 {
+		t.Fatalf("max timestamp of the reopened log is %d at offset %d", timestamp, offset)
+	}
+	for offset := range int64(10) {
+		if got := readBaseOffset(t, partitionLog, offset); got != offset {
+			t.Fatalf("reading offset %d returned the batch at %d", offset, got)
+		}
+	}
+	partitionLog.Close()
+
+	// Age based rolling compares batch timestamps with the first batch of the
+	// active segment, also after a restart.
+	dir = t.TempDir()
+	cfg = storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: 4096}
+	partitionLog, err = storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendValue(t, partitionLog, t0, "first")
+	partitionLog.Close()
+	partitionLog, err = storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer partitionLog.Close()
+	appendValue(t, partitionLog, t0+time.Hour.Milliseconds()/2, "half an hour later")
+	if logs := segmentFiles(t, dir, ".log"); len(logs) != 1 {
+		t.Fatalf("a batch within log.roll.ms rolled the segment: %v", logs)
+	}
+	appendValue(t, partitionLog, t0+time.Hour.Milliseconds(), "an hour later")
+	if logs := segmentFiles(t, dir, ".log"); len(logs) != 2 {
+		t.Fatalf("a batch log.roll.ms after the first did not roll the segment: %v", logs)
+	}
+}
+
+func TestSegmentIndexLookup(t *testing.T) {
+	const t0 = 1726045943832
+	for _, intervalBytes := range []int{0, 4096} {
+		dir := t.TempDir()
+		partitionLog, err := storage.Open(dir, storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: intervalBytes})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range 10 {
+			appendValue(t, partitionLog, t0+int64(i)*1000, fmt.Sprintf("value %d", i))
+		}
+
+		// With an interval of 0 every batch is indexed, otherwise only the first.
+		entries := 1
+		if intervalBytes == 0 {
+			entries = 10
+		}
+		for suffix, entrySize := range map[string]int{".index": 8, ".timeindex": 12} {
+			info, err := os.Stat(filepath.Join(dir, "00000000000000000000"+suffix))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != int64(entries*entrySize) {
+				t.Fatalf("interval %d: %s holds %d bytes, want %d entries", intervalBytes, suffix, info.Size(), entries)
+			}
+		}
+
+		for offset := range int64(10) {
+			if got := readBaseOffset(t, partitionLog, offset); got != offset {
+				t.Fatalf("interval %d: reading offset %d returned the batch at %d", intervalBytes, offset, got)
+			}
+		}
+		// The search starts at or before the first batch reaching the timestamp.
+		for _, tc := range []struct {
+			timestamp int64
+			ok        bool
+		}{{t0, true}, {t0 + 5000, true}, {t0 + 5500, true}, {t0 + 9000, true}, {t0 + 9001, false}} {
+			offset, ok := partitionLog.TimestampSearchOffset(tc.timestamp)
+			first := (tc.timestamp - t0 + 999) / 1000
+			if ok != tc.ok || ok && offset > first {
+				t.Fatalf("interval %d: searching timestamp %d returned offset %d, %v", intervalBytes, tc.timestamp, offset, ok)
+			}
+		}
+		partitionLog.Close()
+	}
+}
+
+func TestRecoveryTruncatesTornBatch(t *testing.T) {
+	dir := t.TempDir()
+	cfg := storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: 0}
+	partitionLog, err := storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		appendValue(t, partitionLog, 1726045943832+int64(i), fmt.Sprintf("value %d", i))
+	}
+	partitionLog.Close()
+
+	// The last batch was only partly written when the broker stopped.
+	segmentPath := filepath.Join(dir, "00000000000000000000.log")
+	info, err := os.Stat(segmentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(segmentPath, info.Size()-5); err != nil {
+		t.Fatal(err)
+	}
+
+	partitionLog, err = storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer partitionLog.Close()
+	if end := partitionLog.LogEndOffset(); end != 2 {
+		t.Fatalf("log end offset after recovery is %d, want 2", end)
+	}
+	if timestamp, offset := partitionLog.MaxTimestamp(); timestamp != 1726045943832+1 || offset != 1 {
+		t.Fatalf("max timestamp after recovery is %d at offset %d", timestamp, offset)
+	}
+	if offset := appendValue(t, partitionLog, 1726045943832+3, "after recovery"); offset != 2 {
+		t.Fatalf("the first batch after recovery got offset %d, want 2", offset)
+	}
+	for offset := range int64(3) {
+		if got := readBaseOffset(t, partitionLog, offset); got != offset {
+			t.Fatalf("reading offset %d returned the batch at %d", offset, got)
+		}
+	}
+}
+
+func TestCompressedRecordBatch(t *testing.T) {
+	for _, codec := range []compression.Codec{compression.None, compression.Gzip, compression.Snappy, compression.LZ4, compression.Zstd} {
+		records := []metadata.Record{
+			{Value: bytes.Repeat([]byte("hello "), 100)},
+			{Key: []byte("key"), Value: []byte("world")},
+		}
+		batch := metadata.NewRecordBatch(1726045943832, records)
+		batch.SetCompression(codec)
+		data, err := metadata.EncodeRecordBatch(batch)
+		if err != nil {
+			t.Fatalf("%s: %v", codec, err)
+		}
+		decoded, err := protocol.DecodeClusterMetadata(data, false)
+		if err != nil {
+			t.Fatalf("%s: %v", codec, err)
+		}
+		got := decoded.RecordBatchs[0]
+		if got.Compression() != codec || len(got.Records) != len(records) {
+			t.Fatalf("%s: decoded %s batch with %d records", codec, got.Compression(), len(got.Records))
+		}
+		for i, record := range got.Records {
+			if !bytes.Equal(record.Key, records[i].Key) || !bytes.Equal(record.Value, records[i].Value) {
+				t.Fatalf("%s: record %d decoded as %q=%q", codec, i, record.Key, record.Value)
+			}
+		}
+	}
+}
+
+func TestDecompressLimit(t *testing.T) {
+	data := make([]byte, 64<<10)
+	for _, codec := range []compression.Codec{compression.Gzip, compression.Snappy, compression.LZ4, compression.Zstd} {
+		compressed, err := compression.Compress(codec, data)
+		if err != nil {
+			t.Fatalf("%s: %v", codec, err)
+		}
+		if out, err := compression.Decompress(codec, compressed, len(data)); err != nil || len(out) != len(data) {
+			t.Fatalf("%s: decompressing at the limit returned %d bytes, %v", codec, len(out), err)
+		}
+		if _, err := compression.Decompress(codec, compressed, len(data)-1); !errors.Is(err, compression.ErrTooLarge) {
+			t.Fatalf("%s: decompressing over the limit returned %v, want ErrTooLarge", codec, err)
+		}
+	}
+}
+
+func TestProduceMessageTooLarge(t *testing.T) {
+	b := newTestBroker(t, t.TempDir())
+	createTopic(t, b, "foo", 1)
+	const maxMessageBytes = 4096
+	produceHandler := produce.NewProduceHandler(b.images, b.logs, purgatory.New(), inspect.New(inspect.Config{}), maxMessageBytes)
+
+	encode := func(codec compression.Codec, value []byte) []byte {
+		batch := metadata.NewRecordBatch(time.Now().UnixMilli(), []metadata.Record{{Value: value}})
+		batch.SetCompression(codec)
+		data, err := metadata.EncodeRecordBatch(batch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	for _, tc := range []struct {
+		name  string
+		batch []byte
+		code  int16
+	}{
+		{"small batch", encode(compression.None, make([]byte, 1024)), protocol.ErrorCodeNone},
+		{"large batch", encode(compression.None, make([]byte, maxMessageBytes)), protocol.ErrorCodeMessageTooLarge},
+		// The gzip batch is small, but its records decompress beyond the limit.
+		{"gzip bomb", encode(compression.Gzip, make([]byte, 1<<20)), protocol.ErrorCodeMessageTooLarge},
+		{"garbled gzip", corruptGzipBatch(t, encode(compression.Gzip, make([]byte, 1024))), protocol.ErrorCodeCorruptMessage},
+	} {
+		if code := produceBatch(t, b, produceHandler, "foo", tc.batch); code != tc.code {
+			t.Fatalf("producing a %s returned %d, want %d", tc.name, code, tc.code)
+		}
+	}
+}
+
+// corruptGzipBatch garbles the gzip stream of an encoded gzip batch and fixes
+// up its CRC, so that only the decompression fails.
+func corruptGzipBatch(t *testing.T, batch []byte) []byte {
+	t.Helper()
+	batch = slices.Clone(batch)
+	// The records follow the batch header; skip the 10-byte gzip header.
+	for i := metadata.BatchHeaderSize + 10; i < len(batch)-8; i++ {
+		batch[i] ^= 0xff
+	}
+	binary.BigEndian.PutUint32(batch[metadata.BatchCRCPos:], metadata.BatchCRC(batch))
+	return batch
+}
+
+// recordHeadersBatch was encoded by a Kafka client: one record with a null key,
+// value "hi" and the headers trace=ab and k=null.
+var recordHeadersBatch = []byte{
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 70, 0, 0, 0, 0, 2, 66, 197, 64, 168, 0, 0, 0, 0, 0, 0, 0, 0, 1, 145, 224, 90, 248, 24, 0, 0, 1, 145, 224, 90, 248, 24, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 1, 40, 0, 0, 0, 1, 4, 104, 105, 4, 10, 116, 114, 97, 99, 101, 4, 97, 98, 2, 107, 1,
+}
+
+func TestDecodeRecordHeaders(t *testing.T) {
+	clusterMetadata, err := protocol.DecodeClusterMetadata(recordHeadersBatch, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordBatch := clusterMetadata.RecordBatchs[0]
+	record := recordBatch.Records[0]
+	if record.Length != 20 || record.Key != nil || string(record.Value) != "hi" {
+		t.Fatalf("decoded record with length %d, key %q and value %q", record.Length, record.Key, record.Value)
+	}
+	want := []metadata.RecordHeader{{Key: "trace", Value: []byte("ab")}, {Key: "k", Value: nil}}
+	if !equalHeaders(record.Headers, want) {
+		t.Fatalf("decoded headers %q, want %q", record.Headers, want)
+	}
+
+	encoded, err := metadata.EncodeRecordBatch(&recordBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, recordHeadersBatch) {
+		t.Fatalf("re-encoded batch differs:\n got %v\nwant %v", encoded, recordHeadersBatch)
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	records := []metadata.Record{
+		{Key: nil, Value: nil},
+		{Key: []byte{}, Value: []byte{}, Headers: []metadata.RecordHeader{{Key: "", Value: []byte{}}}},
+		{
+			TimestampDelta: -(1 << 40),
+			Key:            []byte("key"),
+			Value:          bytes.Repeat([]byte("v"), 300),
+			Headers: []metadata.RecordHeader{
+				{Key: "a", Value: []byte("1")},
+				{Key: "b", Value: nil},
+				{Key: string(bytes.Repeat([]byte("k"), 200)), Value: bytes.Repeat([]byte("h"), 70)},
+			},
+		},
+	}
+	batch := metadata.NewRecordBatch(1726045943832, records)
+	data, err := metadata.EncodeRecordBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := protocol.DecodeClusterMetadata(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := decoded.RecordBatchs[0].Records
+	if len(got) != len(records) {
+		t.Fatalf("decoded %d records, want %d", len(got), len(records))
+	}
+	for i, record := range got {
+		want := batch.Records[i]
+		if record.Length != want.Length || record.TimestampDelta != want.TimestampDelta || record.OffsetDelta != want.OffsetDelta {
+			t.Fatalf("record %d decoded as length %d, timestamp delta %d, offset delta %d", i, record.Length, record.TimestampDelta, record.OffsetDelta)
+		}
+		if !equalBytes(record.Key, want.Key) || !equalBytes(record.Value, want.Value) || !equalHeaders(record.Headers, want.Headers) {
+			t.Fatalf("record %d decoded as %q=%q %q", i, record.Key, record.Value, record.Headers)
+		}
+	}
+}
+
+// equalBytes compares byte slices, telling null apart from empty.
+func equalBytes(a, b []byte) bool {
+	return (a == nil) == (b == nil) && bytes.Equal(a, b)
+}
+
+func equalHeaders(a, b []metadata.RecordHeader) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || !equalBytes(a[i].Value, b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPurgatoryRecoversPanicOnTimeout(t *testing.T) {
+	fetches := purgatory.New()
+	key := purgatory.Key{Topic: "foo", Partition: 0}
+	calls := 0
+	panicked := make(chan any, 1)
+	fetches.TryCompleteElseWatch(10*time.Millisecond, []purgatory.Key{key}, func(force bool) bool {
+		calls++
+		if force {
+			panic("boom")
+		}
+		return false
+	}, func(r any) {
+		panicked <- r
+	})
+
+	select {
+	case r := <-panicked:
+		if r != "boom" {
+			t.Fatalf("recovered %v, want boom", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the panic of the timed out operation was not reported")
+	}
+	// The operation completed with the panic, so it no longer watches key.
+	fetches.Wake(key)
+	if calls != 3 {
+		t.Fatalf("completion ran %d times, want 3", calls)
+	}
+}
+
+func TestMetadataImagePartitionChange(t *testing.T) {
+	dir := t.TempDir()
+	cfg := storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: 4096}
+	metadataLog, err := storage.Open(dir, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer metadataLog.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	images, err := metadataimage.Load(logger, metadataLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topicID := uuid.New()
+	change := &messages.PartitionChangeRecord{}
+	change.SetDefaults()
+	change.TopicId = topicID
+	change.PartitionId = 0
+	change.Leader = 2
+	change.Isr = []int32{2, 3}
+	change.LeaderRecoveryState = 1
+	// Electing the same leader again still bumps the leader epoch.
+	reelect := &messages.PartitionChangeRecord{}
+	reelect.SetDefaults()
+	reelect.TopicId = topicID
+	reelect.PartitionId = 0
+	reelect.Leader = 2
+	err = images.Update(func(*metadataimage.MetadataImage) ([]metadata.MetadataRecord, error) {
+		return []metadata.MetadataRecord{
+			&metadata.GeneratedRecord{Type: metadata.RecordTypeTopic, Message: &messages.TopicRecord{Name: "foo", TopicId: topicID}},
+			&metadata.GeneratedRecord{Type: metadata.RecordTypePartition, Message: &messages.PartitionRecord{PartitionId: 0, TopicId: topicID,
+				Replicas: []int32{1, 2, 3}, Isr: []int32{1, 2, 3}, Leader: 1, LeaderEpoch: 0, PartitionEpoch: 0}},
+			&metadata.GeneratedRecord{Type: metadata.RecordTypePartitionChange, Message: change},
+			&metadata.GeneratedRecord{Type: metadata.RecordTypePartitionChange, Message: reelect},
+		}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The change is applied both when it is appended and when the log is replayed.
+	reloaded, err := metadataimage.Load(logger, metadataLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, image := range []*metadataimage.MetadataImage{images.Image(), reloaded.Image()} {
+		partition, ok := image.TopicByName("foo").Partition(0)
+		if !ok {
+			t.Fatal("partition 0 of foo is missing")
+		}
+		if partition.Leader != 2 || partition.LeaderEpoch != 2 || partition.PartitionEpoch != 2 ||
+			!slices.Equal(partition.Isr, []int32{2, 3}) || !slices.Equal(partition.Replicas, []int32{1, 2, 3}) ||
+			partition.LeaderRecoveryState != 1 {
+			t.Fatalf("partition after the change is %+v", partition)
+		}
+	}
+}
+
+func TestDecoderRejectsInvalidLengths(t *testing.T) {
+	// A request header v2 whose only tagged field claims 2^63 bytes.
+	header := []byte{0, 18, 0, 3, 0, 0, 0, 7, 0xff, 0xff, 1, 0}
+	header = binary.AppendUvarint(header, 1<<63)
+	if _, err := protocol.DecodeRequestHeader(decoder.NewReader(header)); !errors.Is(err, decoder.ErrInvalidLength) {
+		t.Fatalf("decoding an oversized tagged field returned %v, want ErrInvalidLength", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		data   []byte
+		decode func(r *bufio.Reader) error
+	}{
+		{"tagged field count", binary.AppendUvarint(nil, 1<<40), func(r *bufio.Reader) error {
+			_, err := decoder.DecodeTaggedFields(r)
+			return err
+		}},
+		{"compact array length", binary.AppendUvarint(nil, 1<<63), func(r *bufio.Reader) error {
+			_, err := decoder.DecodeFlexArrayLength(r, true)
+			return err
+		}},
+		{"array length", []byte{0x7f, 0xff, 0xff, 0xff}, func(r *bufio.Reader) error {
+			_, err := decoder.DecodeFlexArrayLength(r, false)
+			return err
+		}},
+		{"negative array length", []byte{0xff, 0xff, 0xff, 0xfe}, func(r *bufio.Reader) error {
+			_, err := decoder.DecodeFlexArrayLength(r, false)
+			return err
+		}},
+		{"bytes length", []byte{0x7f, 0xff, 0xff, 0xff, 1, 2}, func(r *bufio.Reader) error {
+			_, err := decoder.DecodeBytes(r)
+			return err
+		}},
+		{"negative bytes length", []byte{0x80, 0, 0, 0}, func(r *bufio.Reader) error {
+			_, err := decoder.DecodeBytes(r)
+			return err
+		}},
+		{"negative string length", []byte{0xff, 0xf0}, func(r *bufio.Reader) error {
+			_, err := decoder.DecodeNullableString(r)
+			return err
+		}},
+		{"compact string length", []byte{10, 'a', 'b'}, func(r *bufio.Reader) error {
+			_, err := decoder.DecodeCompactString(r)
+			return err
+		}},
+		{"varint bytes length", binary.AppendVarint(nil, -2), func(r *bufio.Reader) error {
+			_, err := decoder.DecodeSpecialBytes(r)
+			return err
+		}},
+		{"message array", binary.AppendUvarint(nil, 1<<31), func(r *bufio.Reader) error {
+			return (&messages.MetadataRequest{}).Decode(r, 12)
+		}},
+	} {
+		if err := tc.decode(decoder.NewReader(tc.data)); !errors.Is(err, decoder.ErrInvalidLength) {
+			t.Errorf("%s: decoding returned %v, want ErrInvalidLength", tc.name, err)
+		}
+	}
+
+	// A null array is still accepted.
+	if length, err := decoder.DecodeFlexArrayLength(decoder.NewReader([]byte{0xff, 0xff, 0xff, 0xff}), false); err != nil || length != -1 {
+		t.Fatalf("decoding a null array returned %d, %v", length, err)
+	}
+}
+
+func TestMessageSize(t *testing.T) {
+	name := "cursor"
+	fetch := &messages.FetchResponse{SessionId: 3, Responses: []messages.FetchResponseFetchableTopicResponse{{
+		Topic: "foo",
+		Partitions: []messages.FetchResponsePartitionData{
+			{PartitionIndex: 0, Records: make([]byte, 200), AbortedTransactions: []messages.FetchResponseAbortedTransaction{{ProducerId: 1}}},
+			{PartitionIndex: 1, DivergingEpoch: messages.FetchResponseEpochEndOffset{Epoch: 2, EndOffset: 10}},
+		},
+	}}}
+	describe := &messages.DescribeTopicPartitionsResponse{
+		Topics: []messages.DescribeTopicPartitionsResponseTopic{{Name: &name, Partitions: []messages.DescribeTopicPartitionsResponsePartition{{
+			ReplicaNodes: []int32{1, 2, 3},
+		}}}},
+		NextCursor: &messages.DescribeTopicPartitionsResponseCursor{TopicName: name},
+	}
+	apiVersions := &messages.ApiVersionsResponse{
+		ApiKeys:                []messages.ApiVersionsResponseApiVersion{{ApiKey: 18, MaxVersion: 4}},
+		SupportedFeatures:      []messages.ApiVersionsResponseSupportedFeatureKey{{Name: "metadata.version", MaxVersion: 20}},
+		FinalizedFeaturesEpoch: -1,
+		// Tag 0 is replaced by SupportedFeatures; tag 1000 is encoded again.
+		UnknownTaggedFields: decoder.TaggedFields{{Tag: 0, Data: []byte{1}}, {Tag: 1000, Data: make([]byte, 300)}},
+	}
+	for _, tc := range []struct {
+		message  protocol.Message
+		versions []int16
+	}{
+		{fetch, []int16{0, 4, 11, 12, 16, 17}},
+		{describe, []int16{0}},
+		{&messages.DescribeTopicPartitionsResponse{}, []int16{0}},
+		{apiVersions, []int16{0, 3, 4}},
+		{&messages.MetadataRequest{}, []int16{0, 4, 12}},
+	} {
+		for _, version := range tc.versions {
+			if size, want := tc.message.Size(version), protocol.MessageSize(tc.message, version); size != want {
+				t.Errorf("%T v%d: Size returned %d, encoding writes %d bytes", tc.message, version, size, want)
+			}
+		}
+	}
+}
+
+// panickingHandler is a Heartbeat handler that panics on every request.
+type panickingHandler struct{}
+
+func (panickingHandler) ApiKey() int16     { return protocol.ApiKeyHeartbeat }
+func (panickingHandler) MinVersion() int16 { return 0 }
+func (panickingHandler) MaxVersion() int16 { return 4 }
+
+func (panickingHandler) Handle(*slog.Logger, *bufio.Reader, io.Writer, *protocol.RequestHeader) {
+	panic("boom")
+}
+
+func (panickingHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	return (&messages.HeartbeatResponse{ErrorCode: errorCode}).Encode(w, header.ApiVersion)
+}
+
+// requestFrame returns a length-prefixed request with a v1 header, or a v2
+// header with tagged fields if tagged is not nil, followed by body.
+func requestFrame(apiKey, apiVersion int16, correlationID int32, tagged []byte, body []byte) []byte {
+	frame := binary.BigEndian.AppendUint16(nil, uint16(apiKey))
+	frame = binary.BigEndian.AppendUint16(frame, uint16(apiVersion))
+	frame = binary.BigEndian.AppendUint32(frame, uint32(correlationID))
+	frame = append(frame, 0xff, 0xff) // null client id
+	frame = append(frame, tagged...)
+	frame = append(frame, body...)
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(frame))), frame...)
+}
+
+// readResponse reads a length-prefixed response and returns its correlation ID
+// and a reader over the rest of it.
+func readResponse(t *testing.T, conn io.Reader) (int32, *bufio.Reader) {
+	t.Helper()
+	var length int32
+	if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+		t.Fatalf("failed to read response length: %v", err)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(conn, data); err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	return int32(binary.BigEndian.Uint32(data)), decoder.NewReader(data[4:])
+}
+
+func TestConnectionErrorResponses(t *testing.T) {
+	metadataLog, err := storage.Open(t.TempDir(), storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: 4096})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer metadataLog.Close()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	images, err := metadataimage.Load(logger, metadataLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handlers := []protocol.RequestHandler{panickingHandler{}}
+	handlers = append(handlers, apiversions.NewApiVersionsHandler(images, handlers))
+	byKey := make(map[int16]protocol.RequestHandler)
+	for _, handler := range handlers {
+		byKey[handler.ApiKey()] = handler
+	}
+	client, server := net.Pipe()
+	defer client.Close()
+	go protocol.HandleConnection(logger, server, byKey)
+
+	oversizedTag := binary.AppendUvarint([]byte{1, 0}, 1<<63)
+	badBody := binary.AppendUvarint(nil, 1<<40) // client software name length
+	goodBody := []byte{3, 'g', 'o', 2, '1', 0}
+	go func() {
+		client.Write(requestFrame(999, 0, 1, []byte{0}, nil))
+		client.Write(requestFrame(protocol.ApiKeyApiVersions, 3, 2, oversizedTag, nil))
+		client.Write(requestFrame(protocol.ApiKeyApiVersions, 3, 3, []byte{0}, badBody))
+		client.Write(requestFrame(protocol.ApiKeyHeartbeat, 0, 4, nil, []byte{0, 0}))
+		client.Write(requestFrame(protocol.ApiKeyApiVersions, 3, 5, []byte{0}, goodBody))
+	}()
+
+	// APIs the broker does not know are taken to use flexible versions.
+	correlationID, rd := readResponse(t, client)
+	if _, err := decoder.DecodeTaggedFields(rd); err != nil {
+		t.Fatal(err)
+	}
+	if code, err := decoder.DecodeInt16(rd); correlationID != 1 || err != nil || code != protocol.ErrorCodeUnsupportedVersion {
+		t.Fatalf("unknown API key answered with correlation ID %d and error code %d (%v)", correlationID, code, err)
+	}
+	for _, want := range []struct {
+		correlationID int32
+		errorCode     int16
+	}{{2, protocol.ErrorCodeInvalidRequest}, {3, protocol.ErrorCodeInvalidRequest}} {
+		correlationID, rd := readResponse(t, client)
+		response := &messages.ApiVersionsResponse{}
+		if err := response.Decode(rd, 3); err != nil {
+			t.Fatal(err)
+		}
+		if correlationID != want.correlationID || response.ErrorCode != want.errorCode {
+			t.Fatalf("request %d answered with correlation ID %d and error code %d", want.correlationID, correlationID, response.ErrorCode)
+		}
+	}
+	correlationID, rd = readResponse(t, client)
+	heartbeat := &messages.HeartbeatResponse{}
+	if err := heartbeat.Decode(rd, 0); err != nil || correlationID != 4 || heartbeat.ErrorCode != protocol.ErrorCodeUnknownServerError {
+		t.Fatalf("panicking handler answered with correlation ID %d and error code %d (%v)", correlationID, heartbeat.ErrorCode, err)
+	}
+	// The connection is still served after the failed requests.
+	correlationID, rd = readResponse(t, client)
+	response := &messages.ApiVersionsResponse{}
+	if err := response.Decode(rd, 3); err != nil || correlationID != 5 || response.ErrorCode != protocol.ErrorCodeNone {
+		t.Fatalf("valid request answered with correlation ID %d and error code %d (%v)", correlationID, response.ErrorCode, err)
+	}
+}
+
+// testBroker is the storage and metadata of a broker in a temporary directory.
+type testBroker struct {
+	dir    string
+	log    *slog.Logger
+	logs   *storage.LogManager
+	images *metadataimage.Manager
+}
+
+func newTestBroker(t *testing.T, dir string) *testBroker {
+	t.Helper()
+	logs, err := storage.NewLogManager([]string{dir}, storage.Config{SegmentBytes: 1 << 20, SegmentMs: time.Hour, IndexIntervalBytes: 4096})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { logs.Close() })
+	metadataLog, err := logs.MetadataLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	images, err := metadataimage.Load(logger, metadataLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testBroker{dir: dir, log: logger, logs: logs, images: images}
+}
+
+// roundTrip sends request to handler in the given version and decodes its
+// response into response.
+func roundTrip(t *testing.T, log *slog.Logger, handler protocol.RequestHandler, version int16, request, response protocol.Message) {
+	t.Helper()
+	var body bytes.Buffer
+	if err := request.Encode(&body, version); err != nil {
+		t.Fatal(err)
+	}
+	header := &protocol.RequestHeader{ApiKey: handler.ApiKey(), ApiVersion: version, CorrelationID: 1}
+	var responseBytes []byte
+	if async, ok := handler.(protocol.AsyncRequestHandler); ok {
+		responses := make(chan []byte, 1)
+		async.HandleAsync(log, decoder.NewReader(body.Bytes()), header, func(response []byte) { responses <- response })
+		select {
+		case responseBytes = <-responses:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no response to %T", request)
+		}
+	} else {
+		var w bytes.Buffer
+		handler.Handle(log, decoder.NewReader(body.Bytes()), &w, header)
+		responseBytes = w.Bytes()
+	}
+	rd := decoder.NewReader(responseBytes)
+	if _, err := decoder.DecodeInt32(rd); err != nil {
+		t.Fatalf("failed to decode the correlation ID of the %T: %v", response, err)
+	}
+	if protocol.ResponseHeaderVersion(header.ApiKey, version) >= 1 {
+		if _, err := decoder.DecodeTaggedFields(rd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := response.Decode(rd, version); err != nil {
+		t.Fatalf("failed to decode %T: %v", response, err)
+	}
+}
+
+// createTopic creates a topic with the given number of partitions.
+func createTopic(t *testing.T, b *testBroker, name string, partitions int32) {
+	t.Helper()
+	cfg := &config.Config{NodeID: 1, NumPartitions: 1, DefaultReplicationFactor: 1}
+	request := &messages.CreateTopicsRequest{}
+	request.SetDefaults()
+	topic := messages.CreateTopicsRequestCreatableTopic{}
+	topic.SetDefaults()
+	topic.Name = name
+	topic.NumPartitions = partitions
+	topic.ReplicationFactor = -1
+	request.Topics = []messages.CreateTopicsRequestCreatableTopic{topic}
+	response := &messages.CreateTopicsResponse{}
+	roundTrip(t, b.log, createtopics.NewCreateTopicsHandler(cfg, b.images, b.logs), 7, request, response)
+	if code := response.Topics[0].ErrorCode; code != protocol.ErrorCodeNone {
+		t.Fatalf("creating topic %s returned %d", name, code)
+	}
+}
+
+// produceValue produces a batch with a single record to partition 0 of topic
+// and returns the partition's error code.
+func produceValue(t *testing.T, b *testBroker, handler protocol.RequestHandler, topic string, value string) int16 {
+	t.Helper()
+	batch, err := metadata.EncodeRecordBatch(metadata.NewRecordBatch(time.Now().UnixMilli(), []metadata.Record{{Value: []byte(value)}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return produceBatch(t, b, handler, topic, batch)
+}
+
+// produceBatch produces a raw record batch to partition 0 of topic and returns
+// the partition's error code.
+func produceBatch(t *testing.T, b *testBroker, handler protocol.RequestHandler, topic string, batch []byte) int16 {
+	t.Helper()
+	request := &messages.ProduceRequest{}
+	request.SetDefaults()
+	request.Acks = -1
+	request.TimeoutMs = 1000
+	request.TopicData = []messages.ProduceRequestTopicProduceData{{
+		Name:          topic,
+		PartitionData: []messages.ProduceRequestPartitionProduceData{{Index: 0, Records: batch}},
+	}}
+	response := &messages.ProduceResponse{}
+	roundTrip(t, b.log, handler, 9, request, response)
+	return response.Responses[0].PartitionResponses[0].ErrorCode
+}
+
+func TestReservedTopicsAndDeletedLogs(t *testing.T) {
+	b := newTestBroker(t, t.TempDir())
+	cfg := &config.Config{NodeID: 1, NumPartitions: 1, DefaultReplicationFactor: 1}
+	createHandler := createtopics.NewCreateTopicsHandler(cfg, b.images, b.logs)
+	deleteHandler := deletetopics.NewDeleteTopicsHandler(b.images, b.logs)
+	produceHandler := produce.NewProduceHandler(b.images, b.logs, purgatory.New(), inspect.New(inspect.Config{}), 1048588)
+
+	createTopics := func(names ...string) []int16 {
+		request := &messages.CreateTopicsRequest{}
+		request.SetDefaults()
+		for _, name := range names {
+			topic := messages.CreateTopicsRequestCreatableTopic{}
+			topic.SetDefaults()
+			topic.Name = name
+			topic.NumPartitions = -1
+			topic.ReplicationFactor = -1
+			request.Topics = append(request.Topics, topic)
+		}
+		response := &messages.CreateTopicsResponse{}
+		roundTrip(t, b.log, createHandler, 7, request, response)
+		var codes []int16
+		for _, topic := range response.Topics {
+			codes = append(codes, topic.ErrorCode)
+		}
+		return codes
+	}
+	deleteTopics := func(names ...string) []int16 {
+		request := &messages.DeleteTopicsRequest{}
+		request.SetDefaults()
+		for _, name := range names {
+			request.Topics = append(request.Topics, messages.DeleteTopicsRequestDeleteTopicState{Name: &name})
+		}
+		response := &messages.DeleteTopicsResponse{}
+		roundTrip(t, b.log, deleteHandler, 6, request, response)
+		var codes []int16
+		for _, topic := range response.Responses {
+			codes = append(codes, topic.ErrorCode)
+		}
+		return codes
+	}
+
+	reserved := []string{protocol.ClusterMetadataTopic, protocol.ConsumerOffsetsTopic, protocol.TransactionStateTopic}
+	if codes := createTopics(append(reserved, "foo")...); !slices.Equal(codes, []int16{17, 17, 17, 0}) {
+		t.Fatalf("creating reserved topics and foo returned %v", codes)
+	}
+	if codes := deleteTopics(reserved...); !slices.Equal(codes, []int16{17, 17, 17}) {
+		t.Fatalf("deleting reserved topics returned %v", codes)
+	}
+	if _, err := os.Stat(filepath.Join(b.dir, protocol.ClusterMetadataTopic+"-0")); err != nil {
+		t.Fatalf("the metadata log is gone: %v", err)
+	}
+
+	// A produce that found foo in the image before it was deleted must not
+	// recreate its log.
+	if code := produceValue(t, b, produceHandler, "foo", "before"); code != protocol.ErrorCodeNone {
+		t.Fatalf("producing to foo returned %d", code)
+	}
+	if err := b.logs.DeleteLog("foo", 0); err != nil {
+		t.Fatal(err)
+	}
+	if code := produceValue(t, b, produceHandler, "foo", "after"); code != protocol.ErrorCodeUnknownTopicOrPartition {
+		t.Fatalf("producing to the deleted log of foo returned %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(b.dir, "foo-0")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("the deleted log of foo was recreated: %v", err)
+	}
+
+	// Creating the topic again creates its log again.
+	if codes := deleteTopics("foo"); !slices.Equal(codes, []int16{0}) {
+		t.Fatalf("deleting foo returned %v", codes)
+	}
+	if codes := createTopics("foo"); !slices.Equal(codes, []int16{0}) {
+		t.Fatalf("creating foo again returned %v", codes)
+	}
+	if code := produceValue(t, b, produceHandler, "foo", "again"); code != protocol.ErrorCodeNone {
+		t.Fatalf("producing to the new foo returned %d", code)
+	}
+}
+
+func TestMetadataInternalTopics(t *testing.T) {
+	b := newTestBroker(t, t.TempDir())
+	// Only Kafka's own topics are internal, not every topic starting with "__".
+	createTopic(t, b, "__foo", 1)
+	cfg := &config.Config{NodeID: 1}
+	request := &messages.MetadataRequest{}
+	request.SetDefaults()
+	response := &messages.MetadataResponse{}
+	roundTrip(t, b.log, topicmetadata.NewMetadataHandler(cfg, b.images), 12, request, response)
+	if len(response.Topics) != 1 || *response.Topics[0].Name != "__foo" || response.Topics[0].IsInternal {
+		t.Fatalf("Metadata returned %+v, want __foo not internal", response.Topics)
+	}
+}
+
+// groupHandlers sends the consumer group requests of a test to the handlers
+// of a group coordinator.
+type groupHandlers struct {
+	t         *testing.T
+	log       *slog.Logger
+	join      protocol.RequestHandler
+	sync      protocol.RequestHandler
+	heartbeat protocol.RequestHandler
+	leave     protocol.RequestHandler
+}
+
+func newGroupHandlers(t *testing.T, b *testBroker) *groupHandlers {
+	groupCoordinator := coordinator.NewGroupCoordinator(b.log, b.logs)
+	return &groupHandlers{
+		t:         t,
+		log:       b.log,
+		join:      joingroup.NewJoinGroupHandler(groupCoordinator),
+		sync:      syncgroup.NewSyncGroupHandler(groupCoordinator),
+		heartbeat: heartbeat.NewHeartbeatHandler(groupCoordinator),
+		leave:     leavegroup.NewLeaveGroupHandler(groupCoordinator),
+	}
+}
+
+func (h *groupHandlers) joinGroup(memberID string, sessionTimeout, rebalanceTimeout time.Duration) *messages.JoinGroupResponse {
+	request := &messages.JoinGroupRequest{
+		GroupId:            "group",
+		SessionTimeoutMs:   int32(sessionTimeout / time.Millisecond),
+		RebalanceTimeoutMs: int32(rebalanceTimeout / time.Millisecond),
+		MemberId:           memberID,
+		ProtocolType:       "consumer",
+		Protocols:          []messages.JoinGroupRequestProtocol{{Name: "range", Metadata: []byte(memberID)}},
+	}
+	response := &messages.JoinGroupResponse{}
+	roundTrip(h.t, h.log, h.join, 5, request, response)
+	return response
+}
+
+// joinNewMember joins a new member, which is first assigned a member id with
+// MEMBER_ID_REQUIRED and then joins with it.
+func (h *groupHandlers) joinNewMember(sessionTimeout, rebalanceTimeout time.Duration) string {
+	response := h.joinGroup("", sessionTimeout, rebalanceTimeout)
+	if response.ErrorCode != protocol.ErrorCodeMemberIDRequired || response.MemberId == "" {
+		h.t.Fatalf("first JoinGroup returned error %d and member id %q", response.ErrorCode, response.MemberId)
+	}
+	return response.MemberId
+}
+
+func (h *groupHandlers) syncGroup(memberID string, generationID int32, assignments map[string]string) *messages.SyncGroupResponse {
+	request := &messages.SyncGroupRequest{GroupId: "group", GenerationId: generationID, MemberId: memberID}
+	for id, assignment := range assignments {
+		request.Assignments = append(request.Assignments, messages.SyncGroupRequestAssignment{MemberId: id, Assignment: []byte(assignment)})
+	}
+	response := &messages.SyncGroupResponse{}
+	roundTrip(h.t, h.log, h.sync, 5, request, response)
+	return response
+}
+
+func (h *groupHandlers) heartbeatGroup(memberID string, generationID int32) int16 {
+	response := &messages.HeartbeatResponse{}
+	roundTrip(h.t, h.log, h.heartbeat, 3, &messages.HeartbeatRequest{GroupId: "group", GenerationId: generationID, MemberId: memberID}, response)
+	return response.ErrorCode
+}
+
+func (h *groupHandlers) leaveGroup(memberID string) *messages.LeaveGroupResponse {
+	request := &messages.LeaveGroupRequest{GroupId: "group", Members: []messages.LeaveGroupRequestMemberIdentity{{MemberId: memberID}}}
+	response := &messages.LeaveGroupResponse{}
+	roundTrip(h.t, h.log, h.leave, 3, request, response)
+	return response
+}
+
+func TestGroupRebalance(t *testing.T) {
+	h := newGroupHandlers(t, newTestBroker(t, t.TempDir()))
+	const sessionTimeout = time.Minute
+
+	// A single member completes the join phase at once and becomes the leader.
+	a := h.joinNewMember(sessionTimeout, 300*time.Millisecond)
+	joined := h.joinGroup(a, sessionTimeout, 300*time.Millisecond)
+	if joined.ErrorCode != protocol.ErrorCodeNone || joined.GenerationId != 1 || joined.Leader != a || len(joined.Members) != 1 {
+		t.Fatalf("JoinGroup of the first member returned %+v", joined)
+	}
+	if code := h.heartbeatGroup(a, 1); code != protocol.ErrorCodeNone {
+		t.Fatalf("heartbeat while completing the rebalance returned %d", code)
+	}
+	synced := h.syncGroup(a, 1, map[string]string{a: "assignment-a"})
+	if synced.ErrorCode != protocol.ErrorCodeNone || string(synced.Assignment) != "assignment-a" || *synced.ProtocolName != "range" {
+		t.Fatalf("SyncGroup of the leader returned %+v", synced)
+	}
+	if code := h.heartbeatGroup(a, 0); code != protocol.ErrorCodeIllegalGeneration {
+		t.Fatalf("heartbeat with an old generation returned %d", code)
+	}
+
+	// A second member starts a rebalance. The first one does not rejoin, so
+	// the join phase is completed by the rebalance timer without it.
+	b := h.joinNewMember(sessionTimeout, 300*time.Millisecond)
+	delayed := make(chan *messages.JoinGroupResponse, 1)
+	started := time.Now()
+	go func() { delayed <- h.joinGroup(b, sessionTimeout, 300*time.Millisecond) }()
+	for h.heartbeatGroup(a, 1) != protocol.ErrorCodeRebalanceInProgress {
+		if time.Since(started) > time.Second {
+			t.Fatal("heartbeat never reported the rebalance")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	joined = <-delayed
+	if elapsed := time.Since(started); elapsed < 300*time.Millisecond {
+		t.Fatalf("JoinGroup completed after %s, before the rebalance timeout", elapsed)
+	}
+	if joined.ErrorCode != protocol.ErrorCodeNone || joined.GenerationId != 2 || joined.Leader != b || len(joined.Members) != 1 {
+		t.Fatalf("delayed JoinGroup returned %+v", joined)
+	}
+	if code := h.heartbeatGroup(a, 2); code != protocol.ErrorCodeUnknownMemberID {
+		t.Fatalf("heartbeat of the member that did not rejoin returned %d", code)
+	}
+
+	// Both members join the next generation; the follower's SyncGroup waits
+	// for the assignment of the leader.
+	c := h.joinNewMember(sessionTimeout, 300*time.Millisecond)
+	followerJoin := make(chan *messages.JoinGroupResponse, 1)
+	go func() { followerJoin <- h.joinGroup(c, sessionTimeout, 300*time.Millisecond) }()
+	for h.heartbeatGroup(b, 2) != protocol.ErrorCodeRebalanceInProgress {
+		time.Sleep(time.Millisecond)
+	}
+	leaderJoin := h.joinGroup(b, sessionTimeout, 300*time.Millisecond)
+	if joined := <-followerJoin; joined.GenerationId != 3 || joined.Leader != b || len(joined.Members) != 0 {
+		t.Fatalf("JoinGroup of the follower returned %+v", joined)
+	}
+	if leaderJoin.GenerationId != 3 || len(leaderJoin.Members) != 2 {
+		t.Fatalf("JoinGroup of the leader returned %+v", leaderJoin)
+	}
+	followerSync := make(chan *messages.SyncGroupResponse, 1)
+	go func() { followerSync <- h.syncGroup(c, 3, nil) }()
+	select {
+	case synced := <-followerSync:
+		t.Fatalf("SyncGroup of the follower returned %+v before the leader synced", synced)
+	case <-time.After(50 * time.Millisecond):
+	}
+	h.syncGroup(b, 3, map[string]string{b: "assignment-b", c: "assignment-c"})
+	if synced := <-followerSync; synced.ErrorCode != protocol.ErrorCodeNone || string(synced.Assignment) != "assignment-c" {
+		t.Fatalf("SyncGroup of the follower returned %+v", synced)
+	}
+
+	// A leaving member triggers a rebalance for the remaining one.
+	left := h.leaveGroup(c)
+	if left.ErrorCode != protocol.ErrorCodeNone || left.Members[0].ErrorCode != protocol.ErrorCodeNone {
+		t.Fatalf("LeaveGroup returned %+v", left)
+	}
+	if code := h.heartbeatGroup(b, 3); code != protocol.ErrorCodeRebalanceInProgress {
+		t.Fatalf("heartbeat after a member left returned %d", code)
+	}
+	if left := h.leaveGroup(c); left.Members[0].ErrorCode != protocol.ErrorCodeUnknownMemberID {
+		t.Fatalf("leaving twice returned %+v", left)
+	}
+}
+
+func TestGroupSessionTimeout(t *testing.T) {
+	t.Parallel()
+	h := newGroupHandlers(t, newTestBroker(t, t.TempDir()))
+	member := h.joinNewMember(coordinator.MinSessionTimeout, 100*time.Millisecond)
+	if joined := h.joinGroup(member, coordinator.MinSessionTimeout, 100*time.Millisecond); joined.ErrorCode != protocol.ErrorCodeNone {
+		t.Fatalf("JoinGroup returned %+v", joined)
+	}
+	h.syncGroup(member, 1, map[string]string{member: "assignment"})
+	if code := h.heartbeatGroup(member, 1); code != protocol.ErrorCodeNone {
+		t.Fatalf("heartbeat returned %d", code)
+	}
+	// Without further heartbeats the member is removed after its session timeout.
+	time.Sleep(coordinator.MinSessionTimeout + 500*time.Millisecond)
+	if code := h.heartbeatGroup(member, 1); code != protocol.ErrorCodeUnknownMemberID {
+		t.Fatalf("heartbeat after the session timed out returned %d", code)
+	}
+}
+
+// offsetCommitRecord returns a __consumer_offsets record in Kafka's layout:
+// key version 1 and value version 1, or a tombstone if value is nil.
+func offsetCommitRecord(group, topic string, partition int32, value *coordinator.OffsetAndMetadata) metadata.Record {
+	var key bytes.Buffer
+	binary.Write(&key, binary.BigEndian, int16(1))
+	binary.Write(&key, binary.BigEndian, int16(len(group)))
+	key.WriteString(group)
+	binary.Write(&key, binary.BigEndian, int16(len(topic)))
+	key.WriteString(topic)
+	binary.Write(&key, binary.BigEndian, partition)
+	record := metadata.Record{Key: key.Bytes()}
+	if value != nil {
+		var v bytes.Buffer
+		binary.Write(&v, binary.BigEndian, int16(1))
+		binary.Write(&v, binary.BigEndian, value.Offset)
+		binary.Write(&v, binary.BigEndian, int16(len(value.Metadata)))
+		v.WriteString(value.Metadata)
+		binary.Write(&v, binary.BigEndian, value.CommitTimestamp)
+		binary.Write(&v, binary.BigEndian, int64(-1)) // expire timestamp
+		record.Value = v.Bytes()
+	}
+	return record
+}
+
+func TestOffsetsReplay(t *testing.T) {
+	dir := t.TempDir()
+	b := newTestBroker(t, dir)
+	groups := coordinator.NewGroupCoordinator(b.log, b.logs)
+	if err := groups.LoadOffsets(); err != nil {
+		t.Fatal(err)
+	}
+	commit := func(group string, offsets map[coordinator.TopicPartition]coordinator.OffsetAndMetadata) {
+		t.Helper()
+		if code := groups.CommitOffsets(coordinator.CommitParams{GroupID: group, GenerationID: -1, Offsets: offsets}); code != protocol.ErrorCodeNone {
+			t.Fatalf("committing offsets of %s returned %d", group, code)
+		}
+	}
+	foo0 := coordinator.TopicPartition{Topic: "foo", Partition: 0}
+	foo1 := coordinator.TopicPartition{Topic: "foo", Partition: 1}
+	bar0 := coordinator.TopicPartition{Topic: "bar", Partition: 0}
+	commit("a", map[coordinator.TopicPartition]coordinator.OffsetAndMetadata{
+		foo0: {Offset: 5, LeaderEpoch: 1, Metadata: "first"},
+		foo1: {Offset: 3, LeaderEpoch: 1},
+	})
+	commit("b", map[coordinator.TopicPartition]coordinator.OffsetAndMetadata{bar0: {Offset: 1, LeaderEpoch: -1}})
+	commit("a", map[coordinator.TopicPartition]coordinator.OffsetAndMetadata{foo0: {Offset: 7, LeaderEpoch: 2, Metadata: "second"}})
+
+	// Records written by Kafka: a tombstone, an older value version and group
+	// metadata, which is not an offset commit.
+	groupMetadataKey := []byte{0, 2, 0, 1, 'a'}
+	batch, err := metadata.EncodeRecordBatch(metadata.NewRecordBatch(time.Now().UnixMilli(), []metadata.Record{
+		offsetCommitRecord("a", "foo", 1, nil),
+		offsetCommitRecord("b", "bar", 1, &coordinator.OffsetAndMetadata{Offset: 9, Metadata: "v1", CommitTimestamp: 42}),
+		{Key: groupMetadataKey, Value: []byte{0, 3}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offsetsLog, err := b.logs.Log(coordinator.ConsumerOffsetsTopic, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := offsetsLog.Append(batch); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[coordinator.TopicPartition]coordinator.OffsetAndMetadata{
+		"a": {foo0: {Offset: 7, LeaderEpoch: 2, Metadata: "second"}},
+		"b": {bar0: {Offset: 1, LeaderEpoch: -1}, {Topic: "bar", Partition: 1}: {Offset: 9, LeaderEpoch: -1, Metadata: "v1", CommitTimestamp: 42}},
+	}
+	b.logs.Close()
+
+	// A restarted coordinator rebuilds the offsets from the log.
+	restarted := coordinator.NewGroupCoordinator(b.log, newTestBroker(t, dir).logs)
+	if err := restarted.LoadOffsets(); err != nil {
+		t.Fatal(err)
+	}
+	for group, offsets := range want {
+		got := restarted.FetchOffsets(group)
+		if len(got) != len(offsets) {
+			t.Fatalf("group %s has offsets %+v after the restart, want %+v", group, got, offsets)
+		}
+		for tp, o := range offsets {
+			g := got[tp]
+			if o.CommitTimestamp == 0 {
+				// Set by the commit.
+				o.CommitTimestamp = g.CommitTimestamp
+			}
+			if g != o {
+				t.Fatalf("group %s has offset %+v for %v after the restart, want %+v", group, g, tp, o)
+			}
+		}
+	}
+}
+
+// fetchSessionClient sends Fetch v12 requests for partitions of topic foo.
+type fetchSessionClient struct {
+	t       *testing.T
+	b       *testBroker
+	handler protocol.RequestHandler
+}
+
+// fetch sends a Fetch request in the given session and epoch, fetching the
+// partitions in offsets from their offset and forgetting the ones in forget.
+func (c *fetchSessionClient) fetch(sessionID, epoch int32, offsets map[int32]int64, forget ...int32) *messages.FetchResponse {
+	c.t.Helper()
+	request := &messages.FetchRequest{}
+	request.SetDefaults()
+	request.MaxWaitMs = 0
+	request.MaxBytes = 1 << 20
+	request.SessionId = sessionID
+	request.SessionEpoch = epoch
+	if len(offsets) > 0 {
+		topic := messages.FetchRequestFetchTopic{Topic: "foo"}
+		for _, index := range slices.Sorted(maps.Keys(offsets)) {
+			partition := messages.FetchRequestFetchPartition{}
+			partition.SetDefaults()
+			partition.Partition = index
+			partition.FetchOffset = offsets[index]
+			partition.PartitionMaxBytes = 1 << 20
+			topic.Partitions = append(topic.Partitions, partition)
+		}
+		request.Topics = []messages.FetchRequestFetchTopic{topic}
+	}
+	if len(forget) > 0 {
+		request.ForgottenTopicsData = []messages.FetchRequestForgottenTopic{{Topic: "foo", Partitions: forget}}
+	}
+	response := &messages.FetchResponse{}
+	roundTrip(c.t, c.b.log, c.handler, 12, request, response)
+	return response
+}
+
+// fetchedPartitions returns the partitions of foo in a Fetch response, with
+// the number of record bytes of each.
+func fetchedPartitions(response *messages.FetchResponse) map[int32]int {
+	partitions := make(map[int32]int)
+	for _, topic := range response.Responses {
+		for _, p := range topic.Partitions {
+			partitions[p.PartitionIndex] = len(p.Records)
+		}
+	}
+	return partitions
+}
+
+func TestFetchSessions(t *testing.T) {
+	b := newTestBroker(t, t.TempDir())
+	createTopic(t, b, "foo", 2)
+	fetches := purgatory.New()
+	produceHandler := produce.NewProduceHandler(b.images, b.logs, fetches, inspect.New(inspect.Config{}), 1048588)
+	client := &fetchSessionClient{t: t, b: b, handler: fetch.NewFetchHandler(b.images, b.logs, fetches, inspect.New(inspect.Config{}))}
+	if code := produceValue(t, b, produceHandler, "foo", "first"); code != protocol.ErrorCodeNone {
+		t.Fatalf("producing to foo returned %d", code)
+	}
+
+	// A full fetch creates a session and returns every partition.
+	response := client.fetch(0, 0, map[int32]int64{0: 0, 1: 0})
+	session := response.SessionId
+	if response.ErrorCode != protocol.ErrorCodeNone || session == 0 {
+		t.Fatalf("full fetch returned error %d and session %d", response.ErrorCode, session)
+	}
+	if got := fetchedPartitions(response); len(got) != 2 || got[0] == 0 || got[1] != 0 {
+		t.Fatalf("full fetch returned partitions %v", got)
+	}
+
+	// Incremental fetches leave out the partitions that did not change.
+	response = client.fetch(session, 1, map[int32]int64{0: 1})
+	if got := fetchedPartitions(response); response.ErrorCode != protocol.ErrorCodeNone || response.SessionId != session || len(got) != 0 {
+		t.Fatalf("incremental fetch without changes returned error %d, session %d and partitions %v", response.ErrorCode, response.SessionId, got)
+	}
+	if code := produceValue(t, b, produceHandler, "foo", "second"); code != protocol.ErrorCodeNone {
+		t.Fatalf("producing to foo returned %d", code)
+	}
+	response = client.fetch(session, 2, nil)
+	if got := fetchedPartitions(response); len(got) != 1 || got[0] == 0 {
+		t.Fatalf("incremental fetch after a produce to partition 0 returned partitions %v", got)
+	}
+
+	// A forgotten partition is no longer fetched.
+	if code := produceValue(t, b, produceHandler, "foo", "third"); code != protocol.ErrorCodeNone {
+		t.Fatalf("producing to foo returned %d", code)
+	}
+	response = client.fetch(session, 3, nil, 0)
+	if got := fetchedPartitions(response); response.ErrorCode != protocol.ErrorCodeNone || len(got) != 0 {
+		t.Fatalf("incremental fetch forgetting partition 0 returned error %d and partitions %v", response.ErrorCode, got)
+	}
+
+	if response = client.fetch(session, 3, nil); response.ErrorCode != protocol.ErrorCodeInvalidFetchSessionEpoch {
+		t.Fatalf("fetch with a reused epoch returned error %d", response.ErrorCode)
+	}
+	if response = client.fetch(session+1, 1, nil); response.ErrorCode != protocol.ErrorCodeFetchSessionIDNotFound {
+		t.Fatalf("fetch in an unknown session returned error %d", response.ErrorCode)
+	}
+
+	// The final epoch closes the session with a sessionless full fetch.
+	response = client.fetch(session, -1, map[int32]int64{0: 0, 1: 0})
+	if got := fetchedPartitions(response); response.ErrorCode != protocol.ErrorCodeNone || response.SessionId != 0 || len(got) != 2 {
+		t.Fatalf("closing fetch returned error %d, session %d and partitions %v", response.ErrorCode, response.SessionId, got)
+	}
+	if response = client.fetch(session, 4, nil); response.ErrorCode != protocol.ErrorCodeFetchSessionIDNotFound {
+		t.Fatalf("fetch in a closed session returned error %d", response.ErrorCode)
+	}
+}
+
+func TestFetchSessionCacheFull(t *testing.T) {
+	b := newTestBroker(t, t.TempDir())
+	createTopic(t, b, "foo", 1)
+	client := &fetchSessionClient{t: t, b: b, handler: fetch.NewFetchHandler(b.images, b.logs, purgatory.New(), inspect.New(inspect.Config{}))}
+
+	// The cache holds 1000 sessions, none of which has been idle long enough
+	// to be evicted.
+	for i := range 1000 {
+		if response := client.fetch(0, 0, nil); response.SessionId == 0 {
+			t.Fatalf("full fetch %d got no session", i)
+		}
+	}
+	// Without room for a session, a full fetch is answered without one.
+	response := client.fetch(0, 0, map[int32]int64{0: 0})
+	if got := fetchedPartitions(response); response.ErrorCode != protocol.ErrorCodeNone || response.SessionId != 0 || len(got) != 1 {
+		t.Fatalf("full fetch with a full cache returned error %d, session %d and partitions %v", response.ErrorCode, response.SessionId, got)
+	}
+}
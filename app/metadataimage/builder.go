@@ -0,0 +1,173 @@
+package metadataimage
+
+import (
+	"maps"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/google/uuid"
+)
+
+// builder applies metadata records on top of a base image. The base image is
+// never modified: maps and topics are copied the first time they change.
+type builder struct {
+	image        *MetadataImage
+	copiedTopics map[uuid.UUID]bool
+	copiedConfig map[string]bool
+	copiedMaps   bool
+}
+
+func newBuilder(base *MetadataImage) *builder {
+	return &builder{
+		image: &MetadataImage{
+			topicsByName:  base.topicsByName,
+			topicsByID:    base.topicsByID,
+			topicConfigs:  base.topicConfigs,
+			featureLevels: base.featureLevels,
+			offset:        base.offset,
+		},
+		copiedTopics: make(map[uuid.UUID]bool),
+		copiedConfig: make(map[string]bool),
+	}
+}
+
+// apply applies a decoded metadata record. It reports false for record types
+// the image does not track.
+func (b *builder) apply(record any) bool {
+	b.copyMaps()
+	if generated, ok := record.(*metadata.GeneratedRecord); ok {
+		record = generated.Message
+	}
+	switch r := record.(type) {
+	case *messages.TopicRecord:
+		topic := &TopicImage{Name: r.Name, ID: r.TopicId, Partitions: make(map[int32]messages.PartitionRecord)}
+		b.image.topicsByName[r.Name] = topic
+		b.image.topicsByID[r.TopicId] = topic
+		b.copiedTopics[r.TopicId] = true
+	case *messages.PartitionRecord:
+		if topic := b.topicForWrite(r.TopicId); topic != nil {
+			topic.Partitions[r.PartitionId] = *r
+		}
+	case *messages.PartitionChangeRecord:
+		topic := b.topicForWrite(r.TopicId)
+		if topic == nil {
+			return false
+		}
+		partition, ok := topic.Partitions[r.PartitionId]
+		if !ok {
+			return false
+		}
+		topic.Partitions[r.PartitionId] = changePartition(partition, r)
+	case *messages.RemoveTopicRecord:
+		if topic, ok := b.image.topicsByID[r.TopicId]; ok {
+			delete(b.image.topicsByID, r.TopicId)
+			if b.image.topicsByName[topic.Name] == topic {
+				delete(b.image.topicsByName, topic.Name)
+				// Topic configs are removed together with the topic.
+				delete(b.image.topicConfigs, topic.Name)
+			}
+		}
+	case *messages.ConfigRecord:
+		if r.ResourceType != metadata.ConfigResourceTypeTopic {
+			return false
+		}
+		configs := b.topicConfigsForWrite(r.ResourceName)
+		if r.Value == nil {
+			delete(configs, r.Name)
+		} else {
+			configs[r.Name] = *r.Value
+		}
+	case *messages.FeatureLevelRecord:
+		if r.FeatureLevel == 0 {
+			delete(b.image.featureLevels, r.Name)
+		} else {
+			b.image.featureLevels[r.Name] = r.FeatureLevel
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// changePartition returns partition with the changes of r applied. Fields of r
+// at their "unchanged" default (-2 for the leader, -1 for the leader recovery
+// state, null for lists) are left alone. Like Kafka, every leader election
+// bumps the leader epoch, even one that keeps the same leader, and every
+// change bumps the partition epoch.
+func changePartition(partition messages.PartitionRecord, r *messages.PartitionChangeRecord) messages.PartitionRecord {
+	if r.Leader != -2 {
+		partition.Leader = r.Leader
+		partition.LeaderEpoch++
+	}
+	if r.Isr != nil {
+		partition.Isr = r.Isr
+	}
+	if r.Replicas != nil {
+		partition.Replicas = r.Replicas
+	}
+	if r.RemovingReplicas != nil {
+		partition.RemovingReplicas = r.RemovingReplicas
+	}
+	if r.AddingReplicas != nil {
+		partition.AddingReplicas = r.AddingReplicas
+	}
+	if r.Directories != nil {
+		partition.Directories = r.Directories
+	}
+	if r.EligibleLeaderReplicas != nil {
+		partition.EligibleLeaderReplicas = r.EligibleLeaderReplicas
+	}
+	if r.LastKnownElr != nil {
+		partition.LastKnownElr = r.LastKnownElr
+	}
+	if r.LeaderRecoveryState != -1 {
+		partition.LeaderRecoveryState = r.LeaderRecoveryState
+	}
+	partition.PartitionEpoch++
+	return partition
+}
+
+// copyMaps copies the top level maps of the base image before the first change.
+func (b *builder) copyMaps() {
+	if b.copiedMaps {
+		return
+	}
+	b.image.topicsByName = maps.Clone(b.image.topicsByName)
+	b.image.topicsByID = maps.Clone(b.image.topicsByID)
+	b.image.topicConfigs = maps.Clone(b.image.topicConfigs)
+	b.image.featureLevels = maps.Clone(b.image.featureLevels)
+	b.copiedMaps = true
+}
+
+func (b *builder) topicForWrite(id uuid.UUID) *TopicImage {
+	topic, ok := b.image.topicsByID[id]
+	if !ok {
+		return nil
+	}
+	if !b.copiedTopics[id] {
+		topic = &TopicImage{Name: topic.Name, ID: topic.ID, Partitions: maps.Clone(topic.Partitions)}
+		b.image.topicsByID[id] = topic
+		b.image.topicsByName[topic.Name] = topic
+		b.copiedTopics[id] = true
+	}
+	return topic
+}
+
+func (b *builder) topicConfigsForWrite(name string) map[string]string {
+	configs, ok := b.image.topicConfigs[name]
+	switch {
+	case !ok:
+		configs = make(map[string]string)
+	case !b.copiedConfig[name]:
+		configs = maps.Clone(configs)
+	}
+	b.image.topicConfigs[name] = configs
+	b.copiedConfig[name] = true
+	return configs
+}
+
+// build returns the image as of offset, the offset of the last record applied.
+func (b *builder) build(offset int64) *MetadataImage {
+	b.image.offset = offset
+	return b.image
+}
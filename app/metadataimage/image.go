@@ -0,0 +1,95 @@
+package metadataimage
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/google/uuid"
+)
+
+// MetadataImage is an immutable snapshot of the cluster metadata, built by
+// applying the records of the cluster metadata log in order. Neither the image
+// nor the values it returns may be modified.
+type MetadataImage struct {
+	topicsByName map[string]*TopicImage
+	topicsByID   map[uuid.UUID]*TopicImage
+	// topicConfigs holds the configs set on each topic, keyed by topic name.
+	topicConfigs  map[string]map[string]string
+	featureLevels map[string]int16
+	// offset is the offset of the last metadata log record applied, or -1.
+	offset int64
+}
+
+// TopicImage is the state of one topic in a MetadataImage.
+type TopicImage struct {
+	Name       string
+	ID         uuid.UUID
+	Partitions map[int32]messages.PartitionRecord
+}
+
+func newMetadataImage() *MetadataImage {
+	return &MetadataImage{
+		topicsByName:  make(map[string]*TopicImage),
+		topicsByID:    make(map[uuid.UUID]*TopicImage),
+		topicConfigs:  make(map[string]map[string]string),
+		featureLevels: make(map[string]int16),
+		offset:        -1,
+	}
+}
+
+// TopicByName returns the topic with the given name, or nil if it does not exist.
+func (m *MetadataImage) TopicByName(name string) *TopicImage {
+	return m.topicsByName[name]
+}
+
+// TopicByID returns the topic with the given id, or nil if it does not exist.
+func (m *MetadataImage) TopicByID(id uuid.UUID) *TopicImage {
+	return m.topicsByID[id]
+}
+
+// TopicNames returns the names of all topics in sorted order.
+func (m *MetadataImage) TopicNames() []string {
+	return slices.Sorted(maps.Keys(m.topicsByName))
+}
+
+// TopicConfigs returns the configs set on a topic.
+func (m *MetadataImage) TopicConfigs(name string) map[string]string {
+	return m.topicConfigs[name]
+}
+
+// Offset returns the offset of the last cluster metadata log record in the
+// image, or -1 if the log is empty.
+func (m *MetadataImage) Offset() int64 {
+	return m.offset
+}
+
+// FeatureLevels returns the finalized feature levels by feature name.
+func (m *MetadataImage) FeatureLevels() map[string]int16 {
+	return m.featureLevels
+}
+
+// Partition returns the partition with the given index.
+func (t *TopicImage) Partition(index int32) (messages.PartitionRecord, bool) {
+	p, ok := t.Partitions[index]
+	return p, ok
+}
+
+// SortedPartitions returns the partitions of the topic ordered by index.
+func (t *TopicImage) SortedPartitions() []messages.PartitionRecord {
+	partitions := make([]messages.PartitionRecord, 0, len(t.Partitions))
+	for _, index := range slices.Sorted(maps.Keys(t.Partitions)) {
+		partitions = append(partitions, t.Partitions[index])
+	}
+	return partitions
+}
+
+// HasPartition reports whether the named topic exists and has the given partition.
+func (m *MetadataImage) HasPartition(topicName string, index int32) bool {
+	topic := m.topicsByName[topicName]
+	if topic == nil {
+		return false
+	}
+	_, ok := topic.Partitions[index]
+	return ok
+}
@@ -0,0 +1,90 @@
+package metadataimage
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+)
+
+// Manager owns the cluster metadata log. It loads the log once on startup and
+// publishes a new MetadataImage for every batch of records it appends.
+type Manager struct {
+	log         *slog.Logger
+	metadataLog *storage.Log
+
+	// mu serializes updates of the metadata log.
+	mu    sync.Mutex
+	image atomic.Pointer[MetadataImage]
+}
+
+// Load replays the cluster metadata log and builds the initial image. An
+// empty log results in an empty image.
+func Load(log *slog.Logger, metadataLog *storage.Log) (*Manager, error) {
+	m := &Manager{log: log, metadataLog: metadataLog}
+	b := newBuilder(newMetadataImage())
+	offset := int64(-1)
+	err := metadataLog.Scan(metadataLog.LogStartOffset(), true, func(batch metadata.RecordBatch) error {
+		for _, record := range batch.Records {
+			b.apply(record.ValueEncodedRecord)
+		}
+		offset = batch.BaseOffset + int64(batch.LastOffsetDelta)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster metadata log: %w", err)
+	}
+	image := b.build(offset)
+	log.Info("Loaded cluster metadata", "dir", metadataLog.Dir(), "topics", len(image.topicsByName), "nextOffset", metadataLog.LogEndOffset())
+	m.image.Store(image)
+	return m, nil
+}
+
+// Image returns the current metadata image.
+func (m *Manager) Image() *MetadataImage {
+	return m.image.Load()
+}
+
+// Update lets update decide which records to add based on the current image,
+// appends them to the metadata log as a single batch and publishes the
+// resulting image. Updates are serialized, so update always sees the records
+// written by earlier updates.
+func (m *Manager) Update(update func(image *MetadataImage) ([]metadata.MetadataRecord, error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.image.Load()
+	metadataRecords, err := update(current)
+	if err != nil {
+		return err
+	}
+	if len(metadataRecords) == 0 {
+		return nil
+	}
+	records := make([]metadata.Record, len(metadataRecords))
+	for i, r := range metadataRecords {
+		records[i].Value, err = metadata.EncodeMetadataRecordValue(r)
+		if err != nil {
+			return err
+		}
+	}
+	batch, err := metadata.EncodeRecordBatch(metadata.NewRecordBatch(time.Now().UnixMilli(), records))
+	if err != nil {
+		return err
+	}
+	baseOffset, err := m.metadataLog.Append(batch)
+	if err != nil {
+		return fmt.Errorf("failed to append to cluster metadata log: %w", err)
+	}
+
+	b := newBuilder(current)
+	for _, r := range metadataRecords {
+		b.apply(r)
+	}
+	m.image.Store(b.build(baseOffset + int64(len(records)) - 1))
+	return nil
+}
@@ -2,29 +2,53 @@ package apiversions
 
 import (
 	"bufio"
+	"cmp"
 	"io"
 	"log/slog"
 	"maps"
 	"slices"
 
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
 )
 
-// SupportedApiVersions maps API keys to their version range
-// Key: ApiKey, Value: MaxVersion (minimum is assumed to be 0 for the *logic*)
-var SupportedApiVersions = map[int16]int16{
-	protocol.ApiKeyApiVersions:             4,  // This handler itself supports up to v4
-	protocol.ApiKeyDescribeTopicPartitions: 0,  // Example: DescribeTopicPartitions support
-	protocol.ApiKeyFetch:                   16, // Example: Fetch support
-	// Add more API keys as they are implemented
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 4
+
+	// FirstFlexibleVersion is the first ApiVersions version using compact encodings and tagged fields.
+	FirstFlexibleVersion int16 = 3
+)
+
+// supportedFeatures are the feature version ranges the broker supports.
+var supportedFeatures = []messages.ApiVersionsResponseSupportedFeatureKey{
+	{Name: "metadata.version", MinVersion: 1, MaxVersion: 20},
 }
 
 // ApiVersionsHandler implements the protocol.RequestHandler interface for ApiVersions requests.
-type ApiVersionsHandler struct{}
+type ApiVersionsHandler struct {
+	images *metadataimage.Manager
+	// versions are the supported version ranges of every API, ordered by API key.
+	versions []messages.ApiVersionsResponseApiVersion
+}
 
-// NewApiVersionsHandler creates a new handler for ApiVersions requests.
-func NewApiVersionsHandler() *ApiVersionsHandler {
-	return &ApiVersionsHandler{}
+// NewApiVersionsHandler creates a new handler for ApiVersions requests that
+// advertises the version ranges declared by handlers, and its own. Finalized
+// features are read from the metadata image.
+func NewApiVersionsHandler(images *metadataimage.Manager, handlers []protocol.RequestHandler) *ApiVersionsHandler {
+	h := &ApiVersionsHandler{images: images}
+	for _, handler := range append(slices.Clone(handlers), protocol.RequestHandler(h)) {
+		h.versions = append(h.versions, messages.ApiVersionsResponseApiVersion{
+			ApiKey:     handler.ApiKey(),
+			MinVersion: handler.MinVersion(),
+			MaxVersion: handler.MaxVersion(),
+		})
+	}
+	slices.SortFunc(h.versions, func(a, b messages.ApiVersionsResponseApiVersion) int {
+		return cmp.Compare(a.ApiKey, b.ApiKey)
+	})
+	return h
 }
 
 // ApiKey returns the API key for ApiVersions requests.
@@ -32,60 +56,70 @@ func (h *ApiVersionsHandler) ApiKey() int16 {
 	return protocol.ApiKeyApiVersions
 }
 
+// MinVersion returns the lowest supported version of ApiVersions requests.
+func (h *ApiVersionsHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of ApiVersions requests.
+func (h *ApiVersionsHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an ApiVersions response carrying errorCode and the
+// supported version ranges. Like Kafka, UNSUPPORTED_VERSION is answered in the
+// v0 layout, which every client can parse, so that the client can retry with a
+// version from the ApiVersions range.
+func (h *ApiVersionsHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	version := header.ApiVersion
+	if errorCode == protocol.ErrorCodeUnsupportedVersion {
+		version = 0
+	}
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.ApiVersionsResponse{ErrorCode: errorCode, ApiKeys: h.versions, FinalizedFeaturesEpoch: -1}
+	return response.Encode(w, version)
+}
+
 // Handle handles the ApiVersions request, using the provided logger.
 func (h *ApiVersionsHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
 	log.Info("Handling ApiVersions request")
-	request, err := DecodeApiVersionsRequest(rd)
+	request := &messages.ApiVersionsRequest{}
+	err := request.Decode(rd, header.ApiVersion)
 	if err != nil {
 		log.Error("Error decoding ApiVersions request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
 		return
 	}
 	log.Info("Received ApiVersions request", "clientSoftwareName", request.ClientSoftwareName, "clientSoftwareVersion", request.ClientSoftwareVersion)
 
-	responseHeader := protocol.ResponseHeaderV0{
-		CorrelationID: header.CorrelationID,
+	response := &messages.ApiVersionsResponse{
+		ErrorCode:              protocol.ErrorCodeNone,
+		ApiKeys:                h.versions,
+		ThrottleTimeMs:         0,
+		FinalizedFeaturesEpoch: -1,
 	}
-
-	var response ApiVersionsResponseV3
-
-	// According to Kafka protocol, ApiVersions V0-V2 request/response is one way (no client software name/version)
-	// V3 adds client software name/version to request and full list of ApiVersions in response.
-	// V4 is the same as V3 for ApiVersions API itself. We use V3 response format for V3+ requests.
-	if header.ApiVersion == 4 { // Check if client is requesting V3 or newer
-		// Build supported versions from our map
-		keys := slices.Collect(maps.Keys(SupportedApiVersions))
-		slices.Sort(keys)
-		versions := make([]ApiVersion, 0, len(SupportedApiVersions))
-		for _, apiKey := range keys {
-			maxVersion := SupportedApiVersions[apiKey]
-			versions = append(versions, ApiVersion{
-				ApiKey:     apiKey,
-				MinVersion: 0, // Assuming min version is 0 for all reported APIs
-				MaxVersion: maxVersion,
+	if header.ApiVersion >= FirstFlexibleVersion {
+		image := h.images.Image()
+		response.SupportedFeatures = supportedFeatures
+		response.FinalizedFeaturesEpoch = image.Offset()
+		for _, name := range slices.Sorted(maps.Keys(image.FeatureLevels())) {
+			level := image.FeatureLevels()[name]
+			response.FinalizedFeatures = append(response.FinalizedFeatures, messages.ApiVersionsResponseFinalizedFeatureKey{
+				Name:            name,
+				MaxVersionLevel: level,
+				MinVersionLevel: level,
 			})
 		}
-
-		response = ApiVersionsResponseV3{
-			ErrorCode:      protocol.ErrorCodeNone,
-			ThrottleTimeMs: 0,
-			ApiVersions:    versions,
-		}
-	} else {
-		// For older request versions (V0-V2), Kafka might return an empty list or a V0 response.
-		// For simplicity here, we'll return an error if an older version is explicitly requested
-		// that doesn't match our primary supported version (e.g. v4 for the ApiVersions API itself).
-		// A more compliant server might try to respond with an older response schema.
-		response = ApiVersionsResponseV3{
-			ErrorCode: protocol.ErrorCodeUnsupportedVersion,
-		}
 	}
 
-	err = responseHeader.Encode(w)
+	err = protocol.EncodeResponseHeader(w, header)
 	if err != nil {
 		log.Error("failed to encode api versions response header", "error", err)
 		return
 	}
-	err = response.Encode(w)
+	err = response.Encode(w, header.ApiVersion)
 	if err != nil {
 		log.Error("failed to encode api versions response", "error", err)
 		return
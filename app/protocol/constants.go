@@ -2,16 +2,54 @@ package protocol
 
 // API Keys
 const (
+	ApiKeyProduce                 int16 = 0
 	ApiKeyFetch                   int16 = 1
+	ApiKeyListOffsets             int16 = 2
+	ApiKeyMetadata                int16 = 3
+	ApiKeyOffsetCommit            int16 = 8
+	ApiKeyOffsetFetch             int16 = 9
+	ApiKeyFindCoordinator         int16 = 10
+	ApiKeyJoinGroup               int16 = 11
+	ApiKeyHeartbeat               int16 = 12
+	ApiKeyLeaveGroup              int16 = 13
+	ApiKeySyncGroup               int16 = 14
 	ApiKeyApiVersions             int16 = 18
+	ApiKeyCreateTopics            int16 = 19
+	ApiKeyDeleteTopics            int16 = 20
 	ApiKeyDescribeTopicPartitions int16 = 75
 	// Add more API keys as needed
 )
 
 // Error Codes
 const (
-	ErrorCodeNone                    int16 = 0
-	ErrorCodeUnknownTopicOrPartition int16 = 3
-	ErrorCodeUnsupportedVersion      int16 = 35
-	ErrorCodeUnknownTopicID          int16 = 100
+	ErrorCodeUnknownServerError        int16 = -1
+	ErrorCodeNone                      int16 = 0
+	ErrorCodeOffsetOutOfRange          int16 = 1
+	ErrorCodeCorruptMessage            int16 = 2
+	ErrorCodeUnknownTopicOrPartition   int16 = 3
+	ErrorCodeMessageTooLarge           int16 = 10
+	ErrorCodeOffsetMetadataTooLarge    int16 = 12
+	ErrorCodeCoordinatorNotAvailable   int16 = 15
+	ErrorCodeNotCoordinator            int16 = 16
+	ErrorCodeInvalidTopicException     int16 = 17
+	ErrorCodeInvalidRequiredAcks       int16 = 21
+	ErrorCodeIllegalGeneration         int16 = 22
+	ErrorCodeInconsistentGroupProtocol int16 = 23
+	ErrorCodeInvalidGroupID            int16 = 24
+	ErrorCodeUnknownMemberID           int16 = 25
+	ErrorCodeInvalidSessionTimeout     int16 = 26
+	ErrorCodeRebalanceInProgress       int16 = 27
+	ErrorCodeUnsupportedVersion        int16 = 35
+	ErrorCodeTopicAlreadyExists        int16 = 36
+	ErrorCodeInvalidPartitions         int16 = 37
+	ErrorCodeInvalidReplicationFactor  int16 = 38
+	ErrorCodeInvalidReplicaAssignment  int16 = 39
+	ErrorCodeInvalidConfig             int16 = 40
+	ErrorCodeInvalidRequest            int16 = 42
+	ErrorCodeKafkaStorageError         int16 = 56
+	ErrorCodeFetchSessionIDNotFound    int16 = 70
+	ErrorCodeInvalidFetchSessionEpoch  int16 = 71
+	ErrorCodeMemberIDRequired          int16 = 79
+	ErrorCodeInvalidRecord             int16 = 87
+	ErrorCodeUnknownTopicID            int16 = 100
 )
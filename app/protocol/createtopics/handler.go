@@ -0,0 +1,163 @@
+package createtopics
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/config"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	MinVersion int16 = 2
+	MaxVersion int16 = 7
+)
+
+// ConfigSourceDynamicTopic is the config_source of configs set on the topic itself.
+const ConfigSourceDynamicTopic int8 = 1
+
+// CreateTopicsHandler implements the protocol.RequestHandler interface for CreateTopics requests.
+type CreateTopicsHandler struct {
+	cfg    *config.Config
+	images *metadataimage.Manager
+	logs   *storage.LogManager
+}
+
+// NewCreateTopicsHandler creates a new handler for CreateTopics requests.
+func NewCreateTopicsHandler(cfg *config.Config, images *metadataimage.Manager, logs *storage.LogManager) *CreateTopicsHandler {
+	return &CreateTopicsHandler{cfg: cfg, images: images, logs: logs}
+}
+
+// ApiKey returns the API key for CreateTopics requests.
+func (h *CreateTopicsHandler) ApiKey() int16 {
+	return protocol.ApiKeyCreateTopics
+}
+
+// MinVersion returns the lowest supported version of CreateTopics requests.
+func (h *CreateTopicsHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of CreateTopics requests.
+func (h *CreateTopicsHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty CreateTopics response.
+func (h *CreateTopicsHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.CreateTopicsResponse{Topics: []messages.CreateTopicsResponseCreatableTopicResult{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the CreateTopics request. The records of all valid topics are
+// appended to the cluster metadata log as one batch, then the partition
+// directories are created.
+func (h *CreateTopicsHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling CreateTopics request", "correlationID", header.CorrelationID)
+	request := &messages.CreateTopicsRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode create topics request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	response := &messages.CreateTopicsResponse{
+		ThrottleTimeMs: 0,
+		Topics:         make([]messages.CreateTopicsResponseCreatableTopicResult, len(request.Topics)),
+	}
+	counts := make(map[string]int, len(request.Topics))
+	for _, t := range request.Topics {
+		counts[t.Name]++
+	}
+	for i, t := range request.Topics {
+		response.Topics[i] = messages.CreateTopicsResponseCreatableTopicResult{Name: t.Name, NumPartitions: -1, ReplicationFactor: -1}
+		if counts[t.Name] > 1 {
+			setTopicError(&response.Topics[i], newTopicError(protocol.ErrorCodeInvalidRequest, "Duplicate topic name."))
+		}
+	}
+
+	brokers := []int32{h.cfg.NodeID}
+	// created maps the index of each topic in the request to its records.
+	created := make(map[int]*newTopic)
+	err = h.images.Update(func(image *metadataimage.MetadataImage) ([]metadata.MetadataRecord, error) {
+		var records []metadata.MetadataRecord
+		for i, t := range request.Topics {
+			topicResponse := &response.Topics[i]
+			if counts[t.Name] > 1 {
+				continue
+			}
+			plan, topicErr := planTopic(t, image, brokers, h.cfg.NumPartitions, h.cfg.DefaultReplicationFactor)
+			if topicErr != nil {
+				setTopicError(topicResponse, topicErr)
+				continue
+			}
+			topicResponse.NumPartitions = int32(len(plan.partitions))
+			topicResponse.ReplicationFactor = plan.replicationFactor
+			topicResponse.Configs = make([]messages.CreateTopicsResponseCreatableTopicConfigs, len(plan.configs))
+			for j, c := range plan.configs {
+				topicResponse.Configs[j] = messages.CreateTopicsResponseCreatableTopicConfigs{ConfigName: c.Name, Value: c.Value, ConfigSource: ConfigSourceDynamicTopic}
+			}
+			if request.ValidateOnly {
+				continue
+			}
+			topicResponse.TopicId = plan.topic.TopicId
+			records = append(records, plan.records()...)
+			created[i] = plan
+		}
+		return records, nil
+	})
+	if err != nil {
+		log.Error("failed to write topics to cluster metadata", "error", err)
+		for i := range created {
+			setTopicError(&response.Topics[i], newTopicError(protocol.ErrorCodeUnknownServerError, "Failed to write topic metadata."))
+			response.Topics[i].TopicId = uuid.Nil
+		}
+		clear(created)
+	}
+	for i, plan := range created {
+		// The topic is in the metadata log already, so a partition whose log
+		// cannot be created is reported to the client as a storage error.
+		var storageErr *topicError
+		for _, p := range plan.partitions {
+			if _, err := h.logs.CreateLog(plan.topic.Name, p.PartitionId); err != nil {
+				log.Error("failed to create partition log", "topic", plan.topic.Name, "partition", p.PartitionId, "error", err)
+				storageErr = newTopicError(protocol.ErrorCodeKafkaStorageError, "Failed to create the log of partition %d.", p.PartitionId)
+			}
+		}
+		if storageErr != nil {
+			setTopicError(&response.Topics[i], storageErr)
+			continue
+		}
+		log.Info("Created topic", "topic", plan.topic.Name, "topicID", plan.topic.TopicId, "partitions", len(plan.partitions))
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode create topics response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode create topics response", "error", err)
+		return
+	}
+	log.Info("Sent CreateTopics response", "topics", len(response.Topics), "validateOnly", request.ValidateOnly)
+}
+
+func setTopicError(t *messages.CreateTopicsResponseCreatableTopicResult, err *topicError) {
+	t.ErrorCode = err.code
+	t.ErrorMessage = &err.message
+	t.NumPartitions = -1
+	t.ReplicationFactor = -1
+	t.Configs = nil
+}
@@ -0,0 +1,265 @@
+package createtopics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/compression"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/google/uuid"
+)
+
+// maxTopicNameLength matches Kafka's limit, which leaves room for the
+// partition suffix in the partition directory name.
+const maxTopicNameLength = 249
+
+// topicConfigNames are the topic level configs accepted by CreateTopics.
+var topicConfigNames = map[string]struct{}{
+	"cleanup.policy":                          {},
+	"compression.gzip.level":                  {},
+	"compression.lz4.level":                   {},
+	"compression.type":                        {},
+	"compression.zstd.level":                  {},
+	"delete.retention.ms":                     {},
+	"file.delete.delay.ms":                    {},
+	"flush.messages":                          {},
+	"flush.ms":                                {},
+	"follower.replication.throttled.replicas": {},
+	"index.interval.bytes":                    {},
+	"leader.replication.throttled.replicas":   {},
+	"local.retention.bytes":                   {},
+	"local.retention.ms":                      {},
+	"max.compaction.lag.ms":                   {},
+	"max.message.bytes":                       {},
+	"message.timestamp.after.max.ms":          {},
+	"message.timestamp.before.max.ms":         {},
+	"message.timestamp.type":                  {},
+	"min.cleanable.dirty.ratio":               {},
+	"min.compaction.lag.ms":                   {},
+	"min.insync.replicas":                     {},
+	"preallocate":                             {},
+	"remote.log.copy.disable":                 {},
+	"remote.log.delete.on.disable":            {},
+	"remote.storage.enable":                   {},
+	"retention.bytes":                         {},
+	"retention.ms":                            {},
+	"segment.bytes":                           {},
+	"segment.index.bytes":                     {},
+	"segment.jitter.ms":                       {},
+	"segment.ms":                              {},
+	"unclean.leader.election.enable":          {},
+}
+
+// topicError is a per-topic failure reported in the CreateTopics response.
+type topicError struct {
+	code    int16
+	message string
+}
+
+func (e *topicError) Error() string {
+	return e.message
+}
+
+func newTopicError(code int16, format string, args ...any) *topicError {
+	return &topicError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// newTopic holds the metadata records describing a topic to create.
+type newTopic struct {
+	topic             messages.TopicRecord
+	partitions        []messages.PartitionRecord
+	configs           []messages.ConfigRecord
+	replicationFactor int16
+}
+
+func (t *newTopic) records() []metadata.MetadataRecord {
+	records := []metadata.MetadataRecord{&metadata.GeneratedRecord{Type: metadata.RecordTypeTopic, Message: &t.topic}}
+	for i := range t.configs {
+		records = append(records, &metadata.GeneratedRecord{Type: metadata.RecordTypeConfig, Message: &t.configs[i]})
+	}
+	for i := range t.partitions {
+		// Version 1 is the first to carry the replica directories.
+		records = append(records, &metadata.GeneratedRecord{Type: metadata.RecordTypePartition, RecordVersion: 1, Message: &t.partitions[i]})
+	}
+	return records
+}
+
+// planTopic validates a topic of the request and builds its records. brokers
+// are the ids of the brokers replicas can be assigned to.
+func planTopic(t messages.CreateTopicsRequestCreatableTopic, image *metadataimage.MetadataImage, brokers []int32, defaultPartitions int32, defaultReplicationFactor int16) (*newTopic, *topicError) {
+	if err := validateTopicName(t.Name); err != nil {
+		return nil, err
+	}
+	if image.TopicByName(t.Name) != nil {
+		return nil, newTopicError(protocol.ErrorCodeTopicAlreadyExists, "Topic '%s' already exists.", t.Name)
+	}
+
+	var assignments [][]int32
+	if len(t.Assignments) > 0 {
+		if t.NumPartitions != -1 {
+			return nil, newTopicError(protocol.ErrorCodeInvalidRequest, "A manual partition assignment was specified, but numPartitions was not set to -1.")
+		}
+		if t.ReplicationFactor != -1 {
+			return nil, newTopicError(protocol.ErrorCodeInvalidRequest, "A manual partition assignment was specified, but replicationFactor was not set to -1.")
+		}
+		var err *topicError
+		assignments, err = validateAssignments(t.Assignments, brokers)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		numPartitions, replicationFactor := t.NumPartitions, t.ReplicationFactor
+		if numPartitions == -1 {
+			numPartitions = defaultPartitions
+		}
+		if replicationFactor == -1 {
+			replicationFactor = defaultReplicationFactor
+		}
+		if numPartitions <= 0 {
+			return nil, newTopicError(protocol.ErrorCodeInvalidPartitions, "Number of partitions was set to an invalid non-positive value.")
+		}
+		if replicationFactor <= 0 {
+			return nil, newTopicError(protocol.ErrorCodeInvalidReplicationFactor, "Replication factor must be larger than 0, or -1 to use the default value.")
+		}
+		if int(replicationFactor) > len(brokers) {
+			return nil, newTopicError(protocol.ErrorCodeInvalidReplicationFactor,
+				"Unable to replicate the partition %d time(s): The target replication factor of %d cannot be reached because only %d broker(s) are registered.",
+				replicationFactor, replicationFactor, len(brokers))
+		}
+		assignments = make([][]int32, numPartitions)
+		for p := range assignments {
+			// Spread the leaders over the brokers round-robin.
+			for r := range int(replicationFactor) {
+				assignments[p] = append(assignments[p], brokers[(p+r)%len(brokers)])
+			}
+		}
+	}
+
+	configs, err := validateConfigs(t.Name, t.Configs)
+	if err != nil {
+		return nil, err
+	}
+
+	topicID := uuid.New()
+	plan := &newTopic{
+		topic:             messages.TopicRecord{Name: t.Name, TopicId: topicID},
+		partitions:        make([]messages.PartitionRecord, len(assignments)),
+		configs:           configs,
+		replicationFactor: int16(len(assignments[0])),
+	}
+	for p, replicas := range assignments {
+		plan.partitions[p] = messages.PartitionRecord{
+			PartitionId:      int32(p),
+			TopicId:          topicID,
+			Replicas:         replicas,
+			Isr:              replicas,
+			RemovingReplicas: []int32{},
+			AddingReplicas:   []int32{},
+			Leader:           replicas[0],
+			LeaderEpoch:      0,
+			PartitionEpoch:   0,
+			// Replicas are not assigned to a log directory yet.
+			Directories: make([]uuid.UUID, len(replicas)),
+		}
+	}
+	return plan, nil
+}
+
+func validateTopicName(name string) *topicError {
+	switch {
+	case name == "":
+		return newTopicError(protocol.ErrorCodeInvalidTopicException, "Topic name is illegal, it can't be empty")
+	case name == "." || name == "..":
+		return newTopicError(protocol.ErrorCodeInvalidTopicException, "Topic name cannot be \".\" or \"..\"")
+	case len(name) > maxTopicNameLength:
+		return newTopicError(protocol.ErrorCodeInvalidTopicException,
+			"Topic name is illegal, it can't be longer than %d characters, topic name: %s", maxTopicNameLength, name)
+	case protocol.IsReservedTopic(name):
+		return newTopicError(protocol.ErrorCodeInvalidTopicException, "Topic name %s is reserved for internal use.", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_' || c == '-') {
+			return newTopicError(protocol.ErrorCodeInvalidTopicException,
+				"Topic name \"%s\" is illegal, it contains a character other than ASCII alphanumerics, '.', '_' and '-'", name)
+		}
+	}
+	return nil
+}
+
+// validateAssignments checks a manual replica assignment and returns the
+// replicas of each partition in partition order.
+func validateAssignments(assignments []messages.CreateTopicsRequestCreatableReplicaAssignment, brokers []int32) ([][]int32, *topicError) {
+	known := make(map[int32]bool, len(brokers))
+	for _, b := range brokers {
+		known[b] = true
+	}
+	sorted := make([]messages.CreateTopicsRequestCreatableReplicaAssignment, len(assignments))
+	copy(sorted, assignments)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PartitionIndex < sorted[j].PartitionIndex })
+
+	replicas := make([][]int32, len(sorted))
+	for i, a := range sorted {
+		if a.PartitionIndex != int32(i) {
+			return nil, newTopicError(protocol.ErrorCodeInvalidReplicaAssignment,
+				"Partitions should be numbered consecutively from 0, but the assignment includes partition %d.", a.PartitionIndex)
+		}
+		if len(a.BrokerIds) == 0 {
+			return nil, newTopicError(protocol.ErrorCodeInvalidReplicaAssignment,
+				"The manual partition assignment includes an empty replica list for partition %d.", a.PartitionIndex)
+		}
+		if len(a.BrokerIds) != len(sorted[0].BrokerIds) {
+			return nil, newTopicError(protocol.ErrorCodeInvalidReplicaAssignment,
+				"All partitions in the manual partition assignment must have the same number of replicas.")
+		}
+		seen := make(map[int32]bool, len(a.BrokerIds))
+		for _, b := range a.BrokerIds {
+			if seen[b] {
+				return nil, newTopicError(protocol.ErrorCodeInvalidReplicaAssignment,
+					"The manual partition assignment includes duplicate replica %d for partition %d.", b, a.PartitionIndex)
+			}
+			if !known[b] {
+				return nil, newTopicError(protocol.ErrorCodeInvalidReplicaAssignment,
+					"The manual partition assignment includes broker %d, but no such broker is registered.", b)
+			}
+			seen[b] = true
+		}
+		replicas[i] = a.BrokerIds
+	}
+	return replicas, nil
+}
+
+// validateConfigs checks the requested topic configs and returns them as config records.
+func validateConfigs(topicName string, configs []messages.CreateTopicsRequestCreateableTopicConfig) ([]messages.ConfigRecord, *topicError) {
+	records := make([]messages.ConfigRecord, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if _, ok := topicConfigNames[c.Name]; !ok {
+			return nil, newTopicError(protocol.ErrorCodeInvalidConfig, "Unknown topic config name: %s", c.Name)
+		}
+		if c.Value == nil {
+			return nil, newTopicError(protocol.ErrorCodeInvalidRequest, "Null value not supported for topic configs: %s", c.Name)
+		}
+		if seen[c.Name] {
+			return nil, newTopicError(protocol.ErrorCodeInvalidRequest, "Duplicate topic config: %s", c.Name)
+		}
+		seen[c.Name] = true
+		value := strings.TrimSpace(*c.Value)
+		if c.Name == "compression.type" {
+			if _, _, err := compression.ParseType(value); err != nil {
+				return nil, newTopicError(protocol.ErrorCodeInvalidConfig,
+					"Invalid value %s for configuration compression.type: String must be one of: %s", value, strings.Join(compression.Types, ", "))
+			}
+		}
+		records = append(records, messages.ConfigRecord{
+			ResourceType: metadata.ConfigResourceTypeTopic,
+			ResourceName: topicName,
+			Name:         c.Name,
+			Value:        &value,
+		})
+	}
+	return records, nil
+}
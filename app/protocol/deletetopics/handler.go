@@ -0,0 +1,167 @@
+package deletetopics
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+	"github.com/google/uuid"
+)
+
+const (
+	MinVersion int16 = 1
+	MaxVersion int16 = 6
+)
+
+// DeleteTopicsHandler implements the protocol.RequestHandler interface for DeleteTopics requests.
+type DeleteTopicsHandler struct {
+	images *metadataimage.Manager
+	logs   *storage.LogManager
+}
+
+// NewDeleteTopicsHandler creates a new handler for DeleteTopics requests.
+func NewDeleteTopicsHandler(images *metadataimage.Manager, logs *storage.LogManager) *DeleteTopicsHandler {
+	return &DeleteTopicsHandler{images: images, logs: logs}
+}
+
+// ApiKey returns the API key for DeleteTopics requests.
+func (h *DeleteTopicsHandler) ApiKey() int16 {
+	return protocol.ApiKeyDeleteTopics
+}
+
+// MinVersion returns the lowest supported version of DeleteTopics requests.
+func (h *DeleteTopicsHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of DeleteTopics requests.
+func (h *DeleteTopicsHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty DeleteTopics response.
+func (h *DeleteTopicsHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.DeleteTopicsResponse{Responses: []messages.DeleteTopicsResponseDeletableTopicResult{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the DeleteTopics request. A RemoveTopicRecord is appended to
+// the cluster metadata log for every deleted topic, then its partition
+// directories are removed.
+func (h *DeleteTopicsHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling DeleteTopics request", "correlationID", header.CorrelationID)
+	request := &messages.DeleteTopicsRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode delete topics request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	topics := request.Topics
+	if header.ApiVersion < 6 {
+		// Versions 1-5 only name the topics to delete.
+		topics = make([]messages.DeleteTopicsRequestDeleteTopicState, len(request.TopicNames))
+		for i, name := range request.TopicNames {
+			topics[i].Name = &name
+		}
+	}
+
+	response := &messages.DeleteTopicsResponse{
+		ThrottleTimeMs: 0,
+		Responses:      make([]messages.DeleteTopicsResponseDeletableTopicResult, len(topics)),
+	}
+	// deleted maps the index of each deleted topic in the request to its partitions.
+	deleted := make(map[int][]messages.PartitionRecord)
+	err = h.images.Update(func(image *metadataimage.MetadataImage) ([]metadata.MetadataRecord, error) {
+		seen := make(map[uuid.UUID]bool)
+		var records []metadata.MetadataRecord
+		for i, t := range topics {
+			topicResponse := &response.Responses[i]
+			topicResponse.Name = t.Name
+			topicResponse.TopicId = t.TopicId
+
+			var topic *metadataimage.TopicImage
+			switch {
+			case t.Name != nil && t.TopicId != uuid.Nil:
+				setError(topicResponse, protocol.ErrorCodeInvalidRequest, "Topic name and topic id cannot both be set.")
+				continue
+			case t.Name != nil && protocol.IsReservedTopic(*t.Name):
+				setError(topicResponse, protocol.ErrorCodeInvalidTopicException, reservedTopicMessage(*t.Name))
+				continue
+			case t.Name != nil:
+				topic = image.TopicByName(*t.Name)
+				if topic == nil {
+					setError(topicResponse, protocol.ErrorCodeUnknownTopicOrPartition, "This server does not host this topic-partition.")
+					continue
+				}
+			default:
+				topic = image.TopicByID(t.TopicId)
+				if topic == nil {
+					setError(topicResponse, protocol.ErrorCodeUnknownTopicID, "This server does not host this topic ID.")
+					continue
+				}
+			}
+			if protocol.IsReservedTopic(topic.Name) {
+				setError(topicResponse, protocol.ErrorCodeInvalidTopicException, reservedTopicMessage(topic.Name))
+				continue
+			}
+			if seen[topic.ID] {
+				setError(topicResponse, protocol.ErrorCodeInvalidRequest, "Duplicate topic in request.")
+				continue
+			}
+			seen[topic.ID] = true
+			name := topic.Name
+			topicResponse.Name = &name
+			topicResponse.TopicId = topic.ID
+			records = append(records, &metadata.GeneratedRecord{Type: metadata.RecordTypeRemoveTopic, Message: &messages.RemoveTopicRecord{TopicId: topic.ID}})
+			deleted[i] = topic.SortedPartitions()
+		}
+		return records, nil
+	})
+	if err != nil {
+		log.Error("failed to write topic removal to cluster metadata", "error", err)
+		for i := range deleted {
+			setError(&response.Responses[i], protocol.ErrorCodeUnknownServerError, "Failed to write topic metadata.")
+		}
+		clear(deleted)
+	}
+	for i, partitions := range deleted {
+		name := *response.Responses[i].Name
+		for _, p := range partitions {
+			if err := h.logs.DeleteLog(name, p.PartitionId); err != nil {
+				log.Error("failed to delete partition log", "topic", name, "partition", p.PartitionId, "error", err)
+			}
+		}
+		log.Info("Deleted topic", "topic", name, "topicID", response.Responses[i].TopicId)
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode delete topics response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode delete topics response", "error", err)
+		return
+	}
+	log.Info("Sent DeleteTopics response", "topics", len(response.Responses))
+}
+
+func setError(t *messages.DeleteTopicsResponseDeletableTopicResult, errorCode int16, message string) {
+	t.ErrorCode = errorCode
+	t.ErrorMessage = &message
+}
+
+func reservedTopicMessage(name string) string {
+	return "Topic " + name + " is reserved for internal use and cannot be deleted."
+}
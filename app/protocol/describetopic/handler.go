@@ -5,16 +5,25 @@ import (
 	"io"
 	"log/slog"
 
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
 	"github.com/google/uuid"
 )
 
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 0
+)
+
 // DescribeTopicHandler implements the protocol.RequestHandler interface for DescribeTopic requests.
-type DescribeTopicHandler struct{}
+type DescribeTopicHandler struct {
+	images *metadataimage.Manager
+}
 
 // NewDescribeTopicHandler creates a new handler for DescribeTopic requests.
-func NewDescribeTopicHandler() *DescribeTopicHandler {
-	return &DescribeTopicHandler{}
+func NewDescribeTopicHandler(images *metadataimage.Manager) *DescribeTopicHandler {
+	return &DescribeTopicHandler{images: images}
 }
 
 // ApiKey returns the API key for DescribeTopic requests.
@@ -22,53 +31,69 @@ func (h *DescribeTopicHandler) ApiKey() int16 {
 	return protocol.ApiKeyDescribeTopicPartitions
 }
 
+// MinVersion returns the lowest supported version of DescribeTopicPartitions requests.
+func (h *DescribeTopicHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of DescribeTopicPartitions requests.
+func (h *DescribeTopicHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty DescribeTopicPartitions response.
+func (h *DescribeTopicHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.DescribeTopicPartitionsResponse{Topics: []messages.DescribeTopicPartitionsResponseTopic{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
 // Handle handles the DescribeTopic request.
 func (h *DescribeTopicHandler) Handle(log *slog.Logger, reader *bufio.Reader, writer io.Writer, header *protocol.RequestHeader) {
 	log.Info("Handling DescribeTopic request")
-	request, err := DecodeDescribeTopicRequest(reader)
+	request := &messages.DescribeTopicPartitionsRequest{}
+	err := request.Decode(reader, header.ApiVersion)
 	if err != nil {
 		log.Error("failed to decode describe topic request", "error", err)
+		writer.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
 		return
 	}
 	log.Info("Received DescribeTopic request", "topics", request.Topics, "responsePartitionLimit", request.ResponsePartitionLimit, "cursor", request.Cursor)
 	responseHeader := &protocol.ResponseHeaderV1{
 		CorrelationID: header.CorrelationID,
 	}
-	response := &DescribeTopicResponse{
-		ThrottleTime: 0,
-		Topics:       make([]TopicResponse, len(request.Topics)),
-		NextCursor:   nil,
-	}
-	clusterMeta, err := protocol.ReadClusterMetadata()
-	if err != nil {
-		log.Error("failed to read cluster metadata", "error", err)
-		return
+	response := &messages.DescribeTopicPartitionsResponse{
+		ThrottleTimeMs: 0,
+		Topics:         make([]messages.DescribeTopicPartitionsResponseTopic, len(request.Topics)),
+		NextCursor:     nil,
 	}
-	topicMap := protocol.GetMapTopicByName(clusterMeta)
+	image := h.images.Image()
 	for i, t := range request.Topics {
-		response.Topics[i] = TopicResponse{
+		response.Topics[i] = messages.DescribeTopicPartitionsResponseTopic{
 			ErrorCode:  protocol.ErrorCodeUnknownTopicOrPartition,
-			Name:       t.Name,
-			TopicID:    uuid.Nil,
+			Name:       &t.Name,
+			TopicId:    uuid.Nil,
 			IsInternal: false,
-			Partitions: []PartitionResponse{},
+			Partitions: []messages.DescribeTopicPartitionsResponsePartition{},
 		}
-		if topic, ok := topicMap[t.Name]; ok {
-			response.Topics[i].TopicID = topic.TopicId
+		if topic := image.TopicByName(t.Name); topic != nil {
+			response.Topics[i].TopicId = topic.ID
 			response.Topics[i].ErrorCode = protocol.ErrorCodeNone
 
-			partitions := protocol.GetPartitionsByTopicId(clusterMeta, topic.TopicId)
-			response.Topics[i].Partitions = make([]PartitionResponse, len(partitions))
+			partitions := topic.SortedPartitions()
+			response.Topics[i].Partitions = make([]messages.DescribeTopicPartitionsResponsePartition, len(partitions))
 			for j, p := range partitions {
-				response.Topics[i].Partitions[j] = PartitionResponse{
+				response.Topics[i].Partitions[j] = messages.DescribeTopicPartitionsResponsePartition{
 					ErrorCode:              protocol.ErrorCodeNone,
 					PartitionIndex:         p.PartitionId,
-					LeaderID:               p.Leader,
+					LeaderId:               p.Leader,
 					LeaderEpoch:            p.LeaderEpoch,
 					ReplicaNodes:           p.Replicas,
 					IsrNodes:               p.Isr,
-					EligibleLeaderReplicas: []int32{},
-					LastKnownELR:           []int32{},
+					EligibleLeaderReplicas: nonNil(p.EligibleLeaderReplicas),
+					LastKnownElr:           nonNil(p.LastKnownElr),
 					OfflineReplicas:        []int32{},
 				}
 			}
@@ -80,10 +105,18 @@ func (h *DescribeTopicHandler) Handle(log *slog.Logger, reader *bufio.Reader, wr
 		log.Error("failed to encode describe topic response header", "error", err)
 		return
 	}
-	err = response.Encode(writer)
+	err = response.Encode(writer, header.ApiVersion)
 	if err != nil {
 		log.Error("failed to encode describe topic response", "error", err)
 		return
 	}
 	log.Info("Sent DescribeTopic response")
 }
+
+// nonNil returns s, or an empty slice for a partition record without ELR fields.
+func nonNil(s []int32) []int32 {
+	if s == nil {
+		return []int32{}
+	}
+	return s
+}
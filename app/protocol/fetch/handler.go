@@ -3,19 +3,44 @@ package fetch
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"io"
 	"log/slog"
+	"runtime/debug"
+	"time"
 
-	"github.com/codecrafters-io/kafka-starter-go/app/encoder"
+	"github.com/codecrafters-io/kafka-starter-go/app/inspect"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
 	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/purgatory"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
 )
 
-// FetchHandler implements the protocol.RequestHandler interface for Fetch requests.
-type FetchHandler struct{}
+// Fetch versions served by the handler: topics are addressed by name up to
+// v12 and by id from v13.
+const (
+	MinVersion int16 = 4
+	MaxVersion int16 = 16
+)
+
+// FetchHandler implements the protocol.AsyncRequestHandler interface for Fetch
+// requests. Fetches that cannot return MinBytes yet are parked in the fetch
+// purgatory until records are produced to one of their partitions or
+// MaxWaitMs expires. Incremental fetch sessions (KIP-227) are kept in a
+// session cache. Served records are passed to the inspector.
+type FetchHandler struct {
+	images    *metadataimage.Manager
+	logs      *storage.LogManager
+	fetches   *purgatory.Purgatory
+	sessions  *sessionCache
+	inspector inspect.Inspector
+}
 
 // NewFetchHandler creates a new handler for Fetch requests.
-func NewFetchHandler() *FetchHandler {
-	return &FetchHandler{}
+func NewFetchHandler(images *metadataimage.Manager, logs *storage.LogManager, fetches *purgatory.Purgatory, inspector inspect.Inspector) *FetchHandler {
+	return &FetchHandler{images: images, logs: logs, fetches: fetches, sessions: newSessionCache(), inspector: inspector}
 }
 
 // ApiKey returns the API key for Fetch requests.
@@ -23,90 +48,201 @@ func (h *FetchHandler) ApiKey() int16 {
 	return protocol.ApiKeyFetch
 }
 
-// Handle handles the Fetch request.
+// MinVersion returns the lowest supported version of Fetch requests.
+func (h *FetchHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of Fetch requests.
+func (h *FetchHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a Fetch response carrying only errorCode.
+func (h *FetchHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.FetchResponse{ErrorCode: errorCode, Responses: []messages.FetchResponseFetchableTopicResponse{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the Fetch request, blocking until it completes.
 func (h *FetchHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	done := make(chan []byte, 1)
+	h.HandleAsync(log, rd, header, func(response []byte) {
+		done <- response
+	})
+	if _, err := w.Write(<-done); err != nil {
+		log.Error("failed to write fetch response", "error", err)
+	}
+}
+
+// HandleAsync handles the Fetch request. Like Kafka, it responds right away
+// when MaxWaitMs is not positive, MinBytes are available or a partition has
+// an error; otherwise the fetch waits for produced records or the timeout.
+func (h *FetchHandler) HandleAsync(log *slog.Logger, rd *bufio.Reader, header *protocol.RequestHeader, respond func(response []byte)) {
 	log.Info("Handling Fetch request", "correlationID", header.CorrelationID)
-	request, err := DecodeFetchRequest(rd)
+	request := &messages.FetchRequest{}
+	err := request.Decode(rd, header.ApiVersion)
 	if err != nil {
 		log.Error("failed to decode fetch request", "error", err)
+		respond(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
 		return
 	}
-	log.Info("Decoded fetch request", "request", request)
 
-	responseHeader := &protocol.ResponseHeaderV1{
-		CorrelationID: header.CorrelationID,
-	}
-	err = responseHeader.Encode(w)
-	if err != nil {
-		log.Error("failed to encode fetch response header", "error", err)
+	fetchCtx, errorCode := h.sessions.newContext(request, time.Now())
+	if errorCode != protocol.ErrorCodeNone {
+		log.Warn("rejecting fetch session", "sessionID", request.SessionId, "sessionEpoch", request.SessionEpoch, "errorCode", errorCode)
+		respond(h.encodeResponse(log, header, &messages.FetchResponse{ErrorCode: errorCode, Responses: []messages.FetchResponseFetchableTopicResponse{}}))
 		return
 	}
 
-	clusterMeta, err := protocol.ReadClusterMetadata()
-	if err != nil {
-		log.Error("failed to read cluster metadata", "error", err)
-		return
+	maxWait := time.Duration(request.MaxWaitMs) * time.Millisecond
+	h.fetches.TryCompleteElseWatch(maxWait, h.watchKeys(fetchCtx.topics), func(force bool) bool {
+		response, size, hasErrors := h.fetch(log, fetchCtx.topics, request.MaxBytes)
+		if !force && maxWait > 0 && len(fetchCtx.topics) > 0 && size < int(request.MinBytes) && !hasErrors {
+			return false
+		}
+		h.sessions.completeResponse(fetchCtx, response)
+		respond(h.encodeResponse(log, header, response))
+		log.Info("Sent Fetch response", "correlationID", header.CorrelationID, "bytes", size)
+		return true
+	}, func(r any) {
+		log.Error("Delayed fetch panicked", "correlationID", header.CorrelationID, "panic", r, "stack", string(debug.Stack()))
+		respond(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeUnknownServerError))
+	})
+}
+
+// watchKeys returns the partitions a parked fetch waits on.
+func (h *FetchHandler) watchKeys(topics []messages.FetchRequestFetchTopic) []purgatory.Key {
+	image := h.images.Image()
+	var keys []purgatory.Key
+	for _, t := range topics {
+		topic, _ := resolveTopic(image, t)
+		if topic == nil {
+			continue
+		}
+		for _, p := range t.Partitions {
+			keys = append(keys, purgatory.Key{Topic: topic.Name, Partition: p.Partition})
+		}
 	}
+	return keys
+}
 
-	response := &FetchResponse{
+// fetch reads the given partitions within maxBytes. It returns the response,
+// the number of record bytes in it, and whether any partition has an error.
+func (h *FetchHandler) fetch(log *slog.Logger, topics []messages.FetchRequestFetchTopic, maxBytes int32) (*messages.FetchResponse, int, bool) {
+	image := h.images.Image()
+	response := &messages.FetchResponse{
 		ThrottleTimeMs: 0,
 		ErrorCode:      protocol.ErrorCodeNone,
-		SessionID:      0,
-		Responses:      make([]TopicResponse, len(request.Topics)),
+		SessionId:      0,
+		Responses:      make([]messages.FetchResponseFetchableTopicResponse, len(topics)),
 	}
-	for i, t := range request.Topics {
-		response.Responses[i] = TopicResponse{
-			TopicID: t.TopicID,
-			Partitions: []PartitionResponse{
-				{
-					PartitionIndex: request.Topics[0].Partitions[0].PartitionID,
-					ErrorCode:      protocol.ErrorCodeNone,
-					HighWatermark:  0,
-				},
-			},
+	// remaining is what is left of the response byte budget (max_bytes).
+	remaining := int(maxBytes)
+	size := 0
+	hasErrors := false
+	for i, t := range topics {
+		topic, unknownTopicErrorCode := resolveTopic(image, t)
+		response.Responses[i] = messages.FetchResponseFetchableTopicResponse{
+			Topic:      t.Topic,
+			TopicId:    t.TopicId,
+			Partitions: make([]messages.FetchResponsePartitionData, len(t.Partitions)),
 		}
-		topicRecord := protocol.GetTopicRecordById(clusterMeta, t.TopicID)
-		if topicRecord == nil {
-			log.Info("unknown topic", "topicID", t.TopicID)
-			response.Responses[i].Partitions[0].RecordBatchsRaw = []byte{0x01}
-			response.Responses[i].Partitions[0].ErrorCode = protocol.ErrorCodeUnknownTopicID
-		} else {
-			recordBatchs, err := protocol.ReadTopicLogFile(topicRecord.Name, request.Topics[i].Partitions[0].PartitionID)
-			if err != nil {
-				log.Error("failed to read topic log", "error", err)
-				// empty topic
-				response.Responses[i].Partitions[0].RecordBatchsRaw = []byte{0x01}
-				continue
-			}
-			raw, err := protocol.ReadTopicLogFileRaw(topicRecord.Name, request.Topics[i].Partitions[0].PartitionID)
-			log.Info("read topic log file", "topicName", topicRecord.Name, "partitionID", request.Topics[i].Partitions[0].PartitionID, "recordBatchs", recordBatchs)
-			if err != nil {
-				log.Error("failed to read topic log", "error", err)
-				continue
+		for j, p := range t.Partitions {
+			partition := &response.Responses[i].Partitions[j]
+			switch {
+			case topic == nil:
+				*partition = newPartitionResponse(p.Partition, unknownTopicErrorCode)
+			case !image.HasPartition(topic.Name, p.Partition):
+				*partition = newPartitionResponse(p.Partition, protocol.ErrorCodeUnknownTopicOrPartition)
+			default:
+				// Like Kafka, the first non-empty partition may exceed the limits
+				// by one batch so that consumers can always make progress.
+				minOneBatch := size == 0
+				*partition = h.readPartition(log, topic.Name, p, min(int(p.PartitionMaxBytes), remaining), minOneBatch)
+				remaining = max(remaining-len(partition.Records), 0)
+				size += len(partition.Records)
 			}
-			log.Info("raw raw", "raw", raw)
-			bufWriter := bytes.NewBuffer(nil)
-			err = encoder.EncodeCompactArrayLength(bufWriter, len(recordBatchs.RecordBatchs))
-			if err != nil {
-				log.Error("failed to encode record batchs length", "error", err)
-				return
-			}
-			// bufWriter.Write(raw)
-			for _, recordBatch := range recordBatchs.RecordBatchs {
-				err = recordBatch.Encode(bufWriter)
-				if err != nil {
-					log.Error("failed to encode record batch", "error", err)
-					return
-				}
-			}
-			response.Responses[i].Partitions[0].RecordBatchsRaw = bufWriter.Bytes()
+			hasErrors = hasErrors || partition.ErrorCode != protocol.ErrorCodeNone
 		}
-		log.Info("response", "response", response.Responses[i], "t", t, "topicRecord", topicRecord)
 	}
-	err = response.Encode(w)
+	return response, size, hasErrors
+}
+
+// resolveTopic finds a requested topic by name (up to v12) or by id (v13+).
+// It also returns the error code for the partitions of an unknown topic.
+func resolveTopic(image *metadataimage.MetadataImage, t messages.FetchRequestFetchTopic) (*metadataimage.TopicImage, int16) {
+	if t.Topic != "" {
+		return image.TopicByName(t.Topic), protocol.ErrorCodeUnknownTopicOrPartition
+	}
+	return image.TopicByID(t.TopicId), protocol.ErrorCodeUnknownTopicID
+}
+
+// encodeResponse encodes the response with its header, or returns nil if that fails.
+func (h *FetchHandler) encodeResponse(log *slog.Logger, header *protocol.RequestHeader, response *messages.FetchResponse) []byte {
+	var buf bytes.Buffer
+	err := protocol.EncodeResponseHeader(&buf, header)
+	if err != nil {
+		log.Error("failed to encode fetch response header", "error", err)
+		return nil
+	}
+	err = response.Encode(&buf, header.ApiVersion)
 	if err != nil {
 		log.Error("failed to encode fetch response", "error", err)
-		return
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// readPartition reads the record batches of a partition starting with the
+// batch that holds the fetch offset, seeking to it through the segment index.
+func (h *FetchHandler) readPartition(log *slog.Logger, topicName string, p messages.FetchRequestFetchPartition, maxBytes int, minOneBatch bool) messages.FetchResponsePartitionData {
+	partitionLog, err := h.logs.Log(topicName, p.Partition)
+	if errors.Is(err, storage.ErrLogDeleted) {
+		// The topic was deleted after it was looked up.
+		return newPartitionResponse(p.Partition, protocol.ErrorCodeUnknownTopicOrPartition)
 	}
-	log.Info("Sent Fetch response")
+	if err != nil {
+		log.Error("failed to open partition log", "topic", topicName, "partition", p.Partition, "error", err)
+		return newPartitionResponse(p.Partition, protocol.ErrorCodeKafkaStorageError)
+	}
+	// There are no followers or transactions, so everything appended is
+	// committed and stable.
+	highWatermark := partitionLog.LogEndOffset()
+	response := newPartitionResponse(p.Partition, protocol.ErrorCodeNone)
+	response.HighWatermark = highWatermark
+	response.LastStableOffset = highWatermark
+	response.LogStartOffset = partitionLog.LogStartOffset()
+	records, err := partitionLog.Read(p.FetchOffset, maxBytes, minOneBatch)
+	switch {
+	case errors.Is(err, storage.ErrOffsetOutOfRange):
+		response.ErrorCode = protocol.ErrorCodeOffsetOutOfRange
+	case errors.Is(err, metadata.ErrCorruptBatch):
+		log.Error("corrupt record batch in partition log", "topic", topicName, "partition", p.Partition, "error", err)
+		return newPartitionResponse(p.Partition, protocol.ErrorCodeCorruptMessage)
+	case err != nil:
+		log.Error("failed to read partition log", "topic", topicName, "partition", p.Partition, "error", err)
+		return newPartitionResponse(p.Partition, protocol.ErrorCodeKafkaStorageError)
+	case records != nil:
+		response.Records = records
+		h.inspector.Inspect(log, inspect.Fetch, topicName, p.Partition, records)
+	}
+	return response
+}
+
+// newPartitionResponse returns a partition response without offsets. The
+// diverging epoch, current leader and snapshot id keep their defaults so they
+// are left out of flexible responses.
+func newPartitionResponse(index int32, errorCode int16) messages.FetchResponsePartitionData {
+	var response messages.FetchResponsePartitionData
+	response.SetDefaults()
+	response.PartitionIndex = index
+	response.ErrorCode = errorCode
+	response.HighWatermark = -1
+	response.AbortedTransactions = []messages.FetchResponseAbortedTransaction{}
+	response.Records = []byte{}
+	return response
 }
@@ -0,0 +1,246 @@
+package fetch
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/google/uuid"
+)
+
+// Fetch session epochs (KIP-227). A request with the initial epoch creates a
+// new session, one with the final epoch is a sessionless full fetch that
+// closes the session it names. Any other epoch continues an existing session.
+const (
+	initialSessionEpoch int32 = 0
+	finalSessionEpoch   int32 = -1
+)
+
+const (
+	// maxFetchSessions is how many sessions are cached (max.incremental.fetch.session.cache.slots).
+	maxFetchSessions = 1000
+	// fetchSessionEvictionTime is how long a session must be idle before a new
+	// one may take its slot when the cache is full (min.incremental.fetch.session.eviction.ms).
+	fetchSessionEvictionTime = 2 * time.Minute
+)
+
+// sessionPartitionKey identifies a partition of a session. Topics are named
+// up to Fetch v12 and identified by id from v13, so only one of topicName and
+// topicID is set.
+type sessionPartitionKey struct {
+	topicName string
+	topicID   uuid.UUID
+	partition int32
+}
+
+// sessionPartition is what a session remembers about one partition: what the
+// client last asked for, and the offsets it was last told.
+type sessionPartition struct {
+	topicName string
+	topicID   uuid.UUID
+	request   messages.FetchRequestFetchPartition
+
+	highWatermark    int64
+	lastStableOffset int64
+	logStartOffset   int64
+}
+
+// update records the offsets sent for the partition and reports whether the
+// response must include it: it has records or an error, or its offsets changed.
+func (p *sessionPartition) update(response *messages.FetchResponsePartitionData) bool {
+	changed := len(response.Records) > 0 ||
+		response.ErrorCode != protocol.ErrorCodeNone ||
+		response.HighWatermark != p.highWatermark ||
+		response.LastStableOffset != p.lastStableOffset ||
+		response.LogStartOffset != p.logStartOffset
+	p.highWatermark = response.HighWatermark
+	p.lastStableOffset = response.LastStableOffset
+	p.logStartOffset = response.LogStartOffset
+	return changed
+}
+
+// fetchSession is an incremental fetch session. Its partitions keep the order
+// in which they were added.
+type fetchSession struct {
+	id int32
+	// epoch is the epoch expected in the next request of the session.
+	epoch      int32
+	partitions []*sessionPartition
+	index      map[sessionPartitionKey]*sessionPartition
+	lastUsed   time.Time
+}
+
+// update adds the requested partitions, refreshes the fetch state of the ones
+// already in the session and drops the forgotten ones.
+func (s *fetchSession) update(topics []messages.FetchRequestFetchTopic, forgotten []messages.FetchRequestForgottenTopic) {
+	for _, t := range topics {
+		for _, p := range t.Partitions {
+			key := sessionPartitionKey{topicName: t.Topic, topicID: t.TopicId, partition: p.Partition}
+			if cached, ok := s.index[key]; ok {
+				cached.request = p
+				continue
+			}
+			cached := &sessionPartition{
+				topicName:        t.Topic,
+				topicID:          t.TopicId,
+				request:          p,
+				highWatermark:    -1,
+				lastStableOffset: -1,
+				logStartOffset:   -1,
+			}
+			s.partitions = append(s.partitions, cached)
+			s.index[key] = cached
+		}
+	}
+	for _, t := range forgotten {
+		for _, partition := range t.Partitions {
+			key := sessionPartitionKey{topicName: t.Topic, topicID: t.TopicId, partition: partition}
+			cached, ok := s.index[key]
+			if !ok {
+				continue
+			}
+			delete(s.index, key)
+			for i, other := range s.partitions {
+				if other == cached {
+					s.partitions = append(s.partitions[:i], s.partitions[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+}
+
+// topics returns the partitions of the session grouped by topic, in session order.
+func (s *fetchSession) topics() []messages.FetchRequestFetchTopic {
+	var topics []messages.FetchRequestFetchTopic
+	for _, p := range s.partitions {
+		if n := len(topics); n > 0 && topics[n-1].Topic == p.topicName && topics[n-1].TopicId == p.topicID {
+			topics[n-1].Partitions = append(topics[n-1].Partitions, p.request)
+			continue
+		}
+		topics = append(topics, messages.FetchRequestFetchTopic{Topic: p.topicName, TopicId: p.topicID, Partitions: []messages.FetchRequestFetchPartition{p.request}})
+	}
+	return topics
+}
+
+// nextSessionEpoch returns the epoch following epoch, wrapping around to 1.
+func nextSessionEpoch(epoch int32) int32 {
+	if epoch == math.MaxInt32 {
+		return 1
+	}
+	return epoch + 1
+}
+
+// fetchContext is what a Fetch request resolves to: the partitions to read and
+// the session, if any, whose state the response updates.
+type fetchContext struct {
+	topics []messages.FetchRequestFetchTopic
+	// session is nil for sessionless fetches, or when the cache had no room for a new session.
+	session *fetchSession
+	// incremental is set when the response only carries changed partitions.
+	incremental bool
+}
+
+// sessionCache holds the fetch sessions of the broker, keyed by session id.
+type sessionCache struct {
+	mu       sync.Mutex
+	sessions map[int32]*fetchSession
+}
+
+func newSessionCache() *sessionCache {
+	return &sessionCache{sessions: make(map[int32]*fetchSession)}
+}
+
+// newContext resolves the session of a Fetch request, creating, closing or
+// updating it as the session id and epoch ask. It returns a non-zero error
+// code when the session is unknown or the epoch is not the expected one.
+func (c *sessionCache) newContext(request *messages.FetchRequest, now time.Time) (*fetchContext, int16) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch request.SessionEpoch {
+	case finalSessionEpoch:
+		delete(c.sessions, request.SessionId)
+		return &fetchContext{topics: request.Topics}, protocol.ErrorCodeNone
+	case initialSessionEpoch:
+		delete(c.sessions, request.SessionId)
+		session := c.create(now)
+		if session != nil {
+			session.update(request.Topics, nil)
+		}
+		return &fetchContext{topics: request.Topics, session: session}, protocol.ErrorCodeNone
+	}
+
+	session, ok := c.sessions[request.SessionId]
+	if !ok {
+		return nil, protocol.ErrorCodeFetchSessionIDNotFound
+	}
+	if session.epoch != request.SessionEpoch {
+		return nil, protocol.ErrorCodeInvalidFetchSessionEpoch
+	}
+	session.update(request.Topics, request.ForgottenTopicsData)
+	session.epoch = nextSessionEpoch(session.epoch)
+	session.lastUsed = now
+	return &fetchContext{topics: session.topics(), session: session, incremental: true}, protocol.ErrorCodeNone
+}
+
+// create adds a new session, evicting sessions idle for longer than
+// fetchSessionEvictionTime when the cache is full. It returns nil when there is
+// still no room. Callers must hold c.mu.
+func (c *sessionCache) create(now time.Time) *fetchSession {
+	if len(c.sessions) >= maxFetchSessions {
+		for id, session := range c.sessions {
+			if now.Sub(session.lastUsed) > fetchSessionEvictionTime {
+				delete(c.sessions, id)
+			}
+		}
+		if len(c.sessions) >= maxFetchSessions {
+			return nil
+		}
+	}
+	id := rand.Int32N(math.MaxInt32) + 1
+	for c.sessions[id] != nil {
+		id = rand.Int32N(math.MaxInt32) + 1
+	}
+	session := &fetchSession{
+		id:       id,
+		epoch:    nextSessionEpoch(initialSessionEpoch),
+		index:    make(map[sessionPartitionKey]*sessionPartition),
+		lastUsed: now,
+	}
+	c.sessions[id] = session
+	return session
+}
+
+// completeResponse sets the session id of a response and records what it
+// tells the client. Incremental responses are trimmed to the partitions that
+// changed since the previous response of the session.
+func (c *sessionCache) completeResponse(fetchCtx *fetchContext, response *messages.FetchResponse) {
+	if fetchCtx.session == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	response.SessionId = fetchCtx.session.id
+	topics := response.Responses[:0]
+	for _, t := range response.Responses {
+		partitions := t.Partitions[:0]
+		for i := range t.Partitions {
+			p := &t.Partitions[i]
+			cached := fetchCtx.session.index[sessionPartitionKey{topicName: t.Topic, topicID: t.TopicId, partition: p.PartitionIndex}]
+			changed := cached == nil || cached.update(p)
+			if changed || !fetchCtx.incremental {
+				partitions = append(partitions, *p)
+			}
+		}
+		t.Partitions = partitions
+		if len(partitions) > 0 || !fetchCtx.incremental {
+			topics = append(topics, t)
+		}
+	}
+	response.Responses = topics
+}
@@ -0,0 +1,122 @@
+package findcoordinator
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/config"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 4
+)
+
+// Coordinator key types.
+const (
+	KeyTypeGroup       int8 = 0
+	KeyTypeTransaction int8 = 1
+)
+
+// FindCoordinatorHandler implements the protocol.RequestHandler interface for FindCoordinator requests.
+// This broker is the coordinator of every consumer group.
+type FindCoordinatorHandler struct {
+	config *config.Config
+}
+
+// NewFindCoordinatorHandler creates a new handler for FindCoordinator requests.
+func NewFindCoordinatorHandler(cfg *config.Config) *FindCoordinatorHandler {
+	return &FindCoordinatorHandler{config: cfg}
+}
+
+// ApiKey returns the API key for FindCoordinator requests.
+func (h *FindCoordinatorHandler) ApiKey() int16 {
+	return protocol.ApiKeyFindCoordinator
+}
+
+// MinVersion returns the lowest supported version of FindCoordinator requests.
+func (h *FindCoordinatorHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of FindCoordinator requests.
+func (h *FindCoordinatorHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a FindCoordinator response carrying only errorCode.
+func (h *FindCoordinatorHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.FindCoordinatorResponse{
+		ErrorCode:    errorCode,
+		NodeId:       -1,
+		Port:         -1,
+		Coordinators: []messages.FindCoordinatorResponseCoordinator{},
+	}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the FindCoordinator request.
+func (h *FindCoordinatorHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling FindCoordinator request", "correlationID", header.CorrelationID)
+	request := &messages.FindCoordinatorRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode find coordinator request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	response := &messages.FindCoordinatorResponse{ThrottleTimeMs: 0}
+	if header.ApiVersion <= 3 {
+		// Versions 0-3 answer a single key at the top level.
+		coordinator := h.coordinator(request.Key, request.KeyType)
+		response.ErrorCode = coordinator.ErrorCode
+		response.ErrorMessage = coordinator.ErrorMessage
+		response.NodeId = coordinator.NodeId
+		response.Host = coordinator.Host
+		response.Port = coordinator.Port
+	} else {
+		response.Coordinators = make([]messages.FindCoordinatorResponseCoordinator, len(request.CoordinatorKeys))
+		for i, key := range request.CoordinatorKeys {
+			response.Coordinators[i] = h.coordinator(key, request.KeyType)
+		}
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode find coordinator response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode find coordinator response", "error", err)
+		return
+	}
+	log.Info("Sent FindCoordinator response")
+}
+
+func (h *FindCoordinatorHandler) coordinator(key string, keyType int8) messages.FindCoordinatorResponseCoordinator {
+	if keyType != KeyTypeGroup {
+		// Transactions are not supported, so there is no transaction coordinator.
+		return messages.FindCoordinatorResponseCoordinator{
+			Key:       key,
+			NodeId:    -1,
+			Host:      "",
+			Port:      -1,
+			ErrorCode: protocol.ErrorCodeCoordinatorNotAvailable,
+		}
+	}
+	return messages.FindCoordinatorResponseCoordinator{
+		Key:       key,
+		NodeId:    h.config.NodeID,
+		Host:      h.config.AdvertisedHost,
+		Port:      int32(h.config.Port),
+		ErrorCode: protocol.ErrorCodeNone,
+	}
+}
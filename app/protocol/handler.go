@@ -4,9 +4,12 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net"
+	"runtime/debug"
+	"sync"
 
 	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
 	"github.com/codecrafters-io/kafka-starter-go/app/encoder"
@@ -16,20 +19,62 @@ import (
 // Make sure RequestHeader is accessible here or move its definition if necessary.
 
 // RequestHandler defines the interface for an API request handler.
+// MinVersion and MaxVersion declare the supported API versions; they are
+// advertised by ApiVersions and requests outside of them are rejected before
+// Handle is called. EncodeErrorResponse writes a complete response, header
+// included, carrying errorCode in the layout of header.ApiVersion; responses
+// without a top-level error code are written empty instead.
 type RequestHandler interface {
 	ApiKey() int16
+	MinVersion() int16
+	MaxVersion() int16
 	Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *RequestHeader)
+	EncodeErrorResponse(w io.Writer, header *RequestHeader, errorCode int16) error
+}
+
+// AsyncRequestHandler is implemented by handlers whose response may complete
+// after HandleAsync returns, such as a JoinGroup waiting for the other members
+// of its group or a Fetch parked until data arrives. The request must be read
+// from rd before HandleAsync returns. respond must be called exactly once, with
+// the encoded response (header included) or with nil when no response is sent.
+type AsyncRequestHandler interface {
+	RequestHandler
+	HandleAsync(log *slog.Logger, rd *bufio.Reader, header *RequestHeader, respond func(response []byte))
 }
 
 // RequestHandlerFunc defines the function signature for API handlers
 // This type might become obsolete or be used internally by concrete handlers if preferred.
 // type RequestHandlerFunc func(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *RequestHeader)
 
+// maxInFlightRequests is how many requests of a connection may await their
+// response before the connection stops reading new ones.
+const maxInFlightRequests = 100
+
+// maxRequestSize is the largest request frame accepted, as Kafka's default
+// socket.request.max.bytes. Larger frames close the connection.
+const maxRequestSize = 100 * 1024 * 1024
+
 // HandleConnection processes a Kafka protocol connection, using the provided logger and a map of registered handlers.
+// Requests are read and dispatched in order while a separate goroutine writes
+// the responses, in the same order, as they complete. This lets a connection
+// keep serving requests while earlier ones, e.g. long-polling fetches, wait.
 func HandleConnection(log *slog.Logger, conn net.Conn, handlers map[int16]RequestHandler) {
+	responses := make(chan chan []byte, maxInFlightRequests)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		writeResponses(log, conn, responses)
+	}()
+	readRequests(log, conn, handlers, responses)
+	close(responses)
+	<-done
+}
+
+// readRequests reads requests until the connection is closed, queueing one
+// response slot per dispatched request.
+func readRequests(log *slog.Logger, conn net.Conn, handlers map[int16]RequestHandler, responses chan<- chan []byte) {
 	for {
-		var length int32
-		err := decoder.DecodeValue(conn, &length)
+		length, err := decoder.DecodeInt32(conn)
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				log.Debug("Connection closed")
@@ -38,13 +83,21 @@ func HandleConnection(log *slog.Logger, conn net.Conn, handlers map[int16]Reques
 			log.Error("invalid message length", "error", err)
 			return
 		}
+		if length < 0 || length > maxRequestSize {
+			log.Error("invalid message length", "length", length)
+			return
+		}
+		// The whole frame is read first, so that lengths read from it can be
+		// checked against the bytes it has left.
+		frame, err := io.ReadAll(io.LimitReader(conn, int64(length)))
+		if err != nil || len(frame) < int(length) {
+			log.Error("failed to read request", "length", length, "error", err)
+			return
+		}
+		rd := decoder.NewReader(frame)
 
-		// Limit the reader to the message length to prevent reading into the next message.
-		lr := io.LimitReader(conn, int64(length))
-		rd := bufio.NewReader(lr) // Use the limited reader
-
-		header, err := DecodeRequestHeader(rd) // DecodeRequestHeader needs to read from rd
-		if err != nil {
+		header, err := decodeRequestHeader(rd)
+		if header == nil {
 			log.Error("Error decoding request header", "error", err)
 			return
 		}
@@ -56,31 +109,152 @@ func HandleConnection(log *slog.Logger, conn net.Conn, handlers map[int16]Reques
 			"clientID", header.ClientID,
 		)
 
-		var bufWriter = bytes.Buffer{}
-		if handler, ok := handlers[header.ApiKey]; ok {
-			// The handler.Handle method now directly takes the bufio.Reader and io.Writer
-			handler.Handle(log, rd, &bufWriter, header)
+		response := make(chan []byte, 1)
+		responses <- response
+		handler, ok := handlers[header.ApiKey]
+		switch {
+		case !ok:
+			log.Warn("Unsupported API key", "correlationID", header.CorrelationID, "apiKey", header.ApiKey)
+			response <- unknownApiKeyResponse(log, header)
+		case err != nil:
+			log.Warn("Error decoding request header", "correlationID", header.CorrelationID, "apiKey", header.ApiKey, "error", err)
+			response <- ErrorResponse(log, handler, header, ErrorCodeInvalidRequest)
+		default:
+			dispatch(log, handler, rd, header, response)
+		}
+	}
+}
+
+// decodeRequestHeader decodes the request header like DecodeRequestHeader,
+// returning a panic while decoding as an error. The header is nil unless its
+// correlation ID was decoded; without it the request cannot be answered.
+func decodeRequestHeader(rd *bufio.Reader) (header *RequestHeader, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			header, err = nil, fmt.Errorf("panic decoding request header: %v", r)
+		}
+	}()
+	return DecodeRequestHeader(rd)
+}
 
-			// Prepare response
-			responseBytes := bufWriter.Bytes()
-			responseLength := int32(len(responseBytes))
+// dispatch passes the request to its handler and sends the response. Requests
+// for an unsupported version get an UNSUPPORTED_VERSION error response, and a
+// panicking handler an UNKNOWN_SERVER_ERROR one, so that a bad request fails
+// on its own instead of taking the connection down.
+func dispatch(log *slog.Logger, handler RequestHandler, rd *bufio.Reader, header *RequestHeader, response chan<- []byte) {
+	var once sync.Once
+	respond := func(responseBytes []byte) {
+		once.Do(func() { response <- responseBytes })
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Handler panicked", "correlationID", header.CorrelationID, "apiKey", header.ApiKey, "panic", r, "stack", string(debug.Stack()))
+			respond(ErrorResponse(log, handler, header, ErrorCodeUnknownServerError))
+		}
+	}()
 
-			// Send response length
-			if err := encoder.EncodeValue(conn, responseLength); err != nil {
-				log.Error("Failed to encode response length", "error", err)
-				return // Important to return on error to prevent further writes
-			}
-			// Send response body
-			if _, err := conn.Write(responseBytes); err != nil {
-				log.Error("Failed to write response body", "error", err)
-				return // Important to return on error
+	if header.ApiVersion < handler.MinVersion() || header.ApiVersion > handler.MaxVersion() {
+		log.Warn("Unsupported API version", "correlationID", header.CorrelationID, "apiKey", header.ApiKey, "apiVersion", header.ApiVersion)
+		respond(ErrorResponse(log, handler, header, ErrorCodeUnsupportedVersion))
+		return
+	}
+	if async, ok := handler.(AsyncRequestHandler); ok {
+		async.HandleAsync(log, rd, header, respond)
+		return
+	}
+	var bufWriter = bytes.Buffer{}
+	handler.Handle(log, rd, &bufWriter, header)
+	respond(bufWriter.Bytes())
+}
+
+// ErrorResponse encodes the error response of handler for the request, or
+// returns nil if that fails. Handlers use it to answer requests they cannot
+// decode. A version the handler does not support is replaced by the nearest
+// supported one, whose layout the response uses.
+func ErrorResponse(log *slog.Logger, handler RequestHandler, header *RequestHeader, errorCode int16) []byte {
+	supported := *header
+	supported.ApiVersion = min(max(header.ApiVersion, handler.MinVersion()), handler.MaxVersion())
+	var bufWriter = bytes.Buffer{}
+	if err := handler.EncodeErrorResponse(&bufWriter, &supported, errorCode); err != nil {
+		log.Error("Failed to encode error response", "errorCode", errorCode, "error", err)
+		return nil
+	}
+	return bufWriter.Bytes()
+}
+
+// EncodeResponse encodes response with the response header for the request,
+// or returns nil if that fails.
+func EncodeResponse(log *slog.Logger, header *RequestHeader, response Message) []byte {
+	var bufWriter = bytes.Buffer{}
+	if err := EncodeResponseHeader(&bufWriter, header); err != nil {
+		log.Error("Failed to encode response header", "apiKey", header.ApiKey, "error", err)
+		return nil
+	}
+	if err := response.Encode(&bufWriter, header.ApiVersion); err != nil {
+		log.Error("Failed to encode response", "apiKey", header.ApiKey, "error", err)
+		return nil
+	}
+	return bufWriter.Bytes()
+}
+
+// RespondAsync waits for the response of an asynchronous handler on a new
+// goroutine and responds with it. A panic while waiting is answered with
+// UNKNOWN_SERVER_ERROR, as in the dispatch path.
+func RespondAsync(log *slog.Logger, handler RequestHandler, header *RequestHeader, respond func(response []byte), wait func() Message) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("Handler panicked", "correlationID", header.CorrelationID, "apiKey", header.ApiKey, "panic", r, "stack", string(debug.Stack()))
+				respond(ErrorResponse(log, handler, header, ErrorCodeUnknownServerError))
 			}
+		}()
+		respond(EncodeResponse(log, header, wait()))
+	}()
+}
 
-		} else {
-			log.Warn("Unsupported API key", "correlationID", header.CorrelationID, "apiKey", header.ApiKey)
-			// Consider sending an error response back to the client for unsupported API keys,
-			// e.g., using a generic error response function if available.
+// unknownApiKeyResponse encodes the response to a request for an API the
+// broker does not implement. The layout of its response is unknown, so it is
+// the response header followed by an UNSUPPORTED_VERSION error code, which is
+// the first field of most responses.
+func unknownApiKeyResponse(log *slog.Logger, header *RequestHeader) []byte {
+	var bufWriter = bytes.Buffer{}
+	if err := EncodeResponseHeader(&bufWriter, header); err != nil {
+		log.Error("Failed to encode unknown API key response", "error", err)
+		return nil
+	}
+	if err := encoder.EncodeInt16(&bufWriter, ErrorCodeUnsupportedVersion); err != nil {
+		log.Error("Failed to encode unknown API key response", "error", err)
+		return nil
+	}
+	return bufWriter.Bytes()
+}
+
+// writeResponses writes the responses in request order, waiting for each one
+// to complete. After a write error the connection is closed, which also stops
+// readRequests, and the remaining responses are dropped.
+func writeResponses(log *slog.Logger, conn net.Conn, responses <-chan chan []byte) {
+	failed := false
+	for response := range responses {
+		responseBytes := <-response
+		if failed || len(responseBytes) == 0 {
+			// Some requests (e.g. Produce with acks=0) do not expect a response.
+			continue
 		}
+		if err := writeResponse(conn, responseBytes); err != nil {
+			log.Error("Failed to write response", "error", err)
+			failed = true
+			conn.Close()
+		}
+	}
+}
 
-	} // End of for loop
+// writeResponse writes one length-prefixed response.
+func writeResponse(w io.Writer, responseBytes []byte) error {
+	if err := encoder.EncodeInt32(w, int32(len(responseBytes))); err != nil {
+		return fmt.Errorf("failed to encode response length: %w", err)
+	}
+	if _, err := w.Write(responseBytes); err != nil {
+		return fmt.Errorf("failed to write response body: %w", err)
+	}
+	return nil
 }
@@ -4,18 +4,30 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"reflect"
 
 	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
 	"github.com/codecrafters-io/kafka-starter-go/app/encoder"
 )
 
+// Request Header v0 => request_api_key request_api_version correlation_id
+//   request_api_key => INT16
+//   request_api_version => INT16
+//   correlation_id => INT32
+//
+// Request Header v1 => request_api_key request_api_version correlation_id client_id
+//   client_id => NULLABLE_STRING
+//
+// Request Header v2 => request_api_key request_api_version correlation_id client_id _tagged_fields
+//
+// The header version follows from the API key and version, see RequestHeaderVersion.
+
 type RequestHeader struct {
 	ApiKey        int16
 	ApiVersion    int16
 	CorrelationID int32
 	ClientID      *string
-	// TaggedFields for request HeaderV2
+	// TaggedFields are the tagged fields of request header v2, none of which are known.
+	TaggedFields decoder.TaggedFields
 }
 
 type ResponseHeaderV0 struct {
@@ -28,7 +40,7 @@ type ResponseHeaderV1 struct {
 }
 
 func (r *ResponseHeaderV0) Encode(w io.Writer) error {
-	err := encoder.EncodeValue(w, r.CorrelationID)
+	err := encoder.EncodeInt32(w, r.CorrelationID)
 	if err != nil {
 		return fmt.Errorf("failed to encode correlation id: %w", err)
 	}
@@ -36,39 +48,58 @@ func (r *ResponseHeaderV0) Encode(w io.Writer) error {
 }
 
 func (r *ResponseHeaderV1) Encode(w io.Writer) error {
-	err := encoder.EncodeValue(w, r.CorrelationID)
+	err := encoder.EncodeInt32(w, r.CorrelationID)
 	if err != nil {
 		return fmt.Errorf("failed to encode correlation id: %w", err)
 	}
 	return encoder.EncodeTaggedField(w)
 }
 
+// EncodeResponseHeader writes the response header for the request, in the
+// version given by ResponseHeaderVersion.
+func EncodeResponseHeader(w io.Writer, header *RequestHeader) error {
+	if ResponseHeaderVersion(header.ApiKey, header.ApiVersion) >= 1 {
+		return (&ResponseHeaderV1{CorrelationID: header.CorrelationID}).Encode(w)
+	}
+	return (&ResponseHeaderV0{CorrelationID: header.CorrelationID}).Encode(w)
+}
+
+// DecodeRequestHeader decodes a request header. If a field after the
+// correlation ID fails to decode, the header decoded so far is returned along
+// with the error, so that the request can still be answered.
 func DecodeRequestHeader(r *bufio.Reader) (*RequestHeader, error) {
 	h := &RequestHeader{}
 	var err error
-	err = decoder.DecodeValue(r, &h.ApiKey)
+	h.ApiKey, err = decoder.DecodeInt16(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode api key: %w", err)
 	}
-	err = decoder.DecodeValue(r, &h.ApiVersion)
+	h.ApiVersion, err = decoder.DecodeInt16(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode api version: %w", err)
 	}
-	err = decoder.DecodeValue(r, &h.CorrelationID)
+	h.CorrelationID, err = decoder.DecodeInt32(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode correlation id: %w", err)
 	}
-	h.ClientID, err = DecodeNullString(r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode client id: %w", err)
+	headerVersion := RequestHeaderVersion(h.ApiKey, h.ApiVersion)
+	if headerVersion >= 1 {
+		h.ClientID, err = DecodeNullString(r)
+		if err != nil {
+			return h, fmt.Errorf("failed to decode client id: %w", err)
+		}
+	}
+	if headerVersion >= 2 {
+		h.TaggedFields, err = decoder.DecodeTaggedFields(r)
+		if err != nil {
+			return h, fmt.Errorf("failed to decode tagged fields: %w", err)
+		}
 	}
-	decoder.DecodeEmptyTaggedField(r)
 	return h, nil
 }
 
 func DecodeNullString(r io.Reader) (*string, error) {
-	var clientIDLength int16
-	err := decoder.DecodeValue(r, &clientIDLength)
+	clientIDLength, err := decoder.DecodeInt16(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode client id length: %w", err)
 	}
@@ -82,22 +113,3 @@ func DecodeNullString(r io.Reader) (*string, error) {
 	}
 	return nil, nil
 }
-
-func EncodeArray(w io.Writer, array any) error {
-	// check if array is a slice
-	if reflect.ValueOf(array).Kind() != reflect.Slice {
-		return fmt.Errorf("array is not a slice")
-	}
-	slice := reflect.ValueOf(array)
-	err := encoder.EncodeUvarint(w, uint64(slice.Len()+1))
-	if err != nil {
-		return fmt.Errorf("failed to encode array length: %w", err)
-	}
-	for i := 0; i < slice.Len(); i++ {
-		err := encoder.EncodeValue(w, slice.Index(i).Interface())
-		if err != nil {
-			return fmt.Errorf("failed to encode array value: %w", err)
-		}
-	}
-	return nil
-}
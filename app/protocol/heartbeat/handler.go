@@ -0,0 +1,78 @@
+package heartbeat
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 4
+)
+
+// HeartbeatHandler implements the protocol.RequestHandler interface for Heartbeat requests.
+type HeartbeatHandler struct {
+	coordinator *coordinator.GroupCoordinator
+}
+
+// NewHeartbeatHandler creates a new handler for Heartbeat requests.
+func NewHeartbeatHandler(groupCoordinator *coordinator.GroupCoordinator) *HeartbeatHandler {
+	return &HeartbeatHandler{coordinator: groupCoordinator}
+}
+
+// ApiKey returns the API key for Heartbeat requests.
+func (h *HeartbeatHandler) ApiKey() int16 {
+	return protocol.ApiKeyHeartbeat
+}
+
+// MinVersion returns the lowest supported version of Heartbeat requests.
+func (h *HeartbeatHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of Heartbeat requests.
+func (h *HeartbeatHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a Heartbeat response carrying only errorCode.
+func (h *HeartbeatHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.HeartbeatResponse{ErrorCode: errorCode}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the Heartbeat request.
+func (h *HeartbeatHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Debug("Handling Heartbeat request", "correlationID", header.CorrelationID)
+	request := &messages.HeartbeatRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode heartbeat request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	response := &messages.HeartbeatResponse{
+		ThrottleTimeMs: 0,
+		ErrorCode:      h.coordinator.Heartbeat(request.GroupId, request.GenerationId, request.MemberId),
+	}
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode heartbeat response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode heartbeat response", "error", err)
+		return
+	}
+	log.Debug("Sent Heartbeat response", "errorCode", response.ErrorCode)
+}
@@ -0,0 +1,136 @@
+package joingroup
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 9
+)
+
+// JoinGroupHandler implements the protocol.AsyncRequestHandler interface for JoinGroup requests.
+type JoinGroupHandler struct {
+	coordinator *coordinator.GroupCoordinator
+}
+
+// NewJoinGroupHandler creates a new handler for JoinGroup requests.
+func NewJoinGroupHandler(groupCoordinator *coordinator.GroupCoordinator) *JoinGroupHandler {
+	return &JoinGroupHandler{coordinator: groupCoordinator}
+}
+
+// ApiKey returns the API key for JoinGroup requests.
+func (h *JoinGroupHandler) ApiKey() int16 {
+	return protocol.ApiKeyJoinGroup
+}
+
+// MinVersion returns the lowest supported version of JoinGroup requests.
+func (h *JoinGroupHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of JoinGroup requests.
+func (h *JoinGroupHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a JoinGroup response carrying only errorCode.
+func (h *JoinGroupHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.JoinGroupResponse{ErrorCode: errorCode, GenerationId: -1, Members: []messages.JoinGroupResponseMember{}}
+	emptyProtocolName(response, header.ApiVersion)
+	return response.Encode(w, header.ApiVersion)
+}
+
+// emptyProtocolName replaces a null protocol name, which versions before 7
+// cannot encode, with an empty one as Kafka does.
+func emptyProtocolName(response *messages.JoinGroupResponse, version int16) {
+	if response.ProtocolName == nil && version < 7 {
+		empty := ""
+		response.ProtocolName = &empty
+	}
+}
+
+// Handle handles the JoinGroup request, blocking until it completes.
+func (h *JoinGroupHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	done := make(chan []byte, 1)
+	h.HandleAsync(log, rd, header, func(response []byte) {
+		done <- response
+	})
+	if _, err := w.Write(<-done); err != nil {
+		log.Error("failed to write join group response", "error", err)
+	}
+}
+
+// HandleAsync handles the JoinGroup request. The response is sent once the join
+// phase of the rebalance completes, which may take up to the rebalance timeout,
+// so it is awaited off the connection's dispatch path.
+func (h *JoinGroupHandler) HandleAsync(log *slog.Logger, rd *bufio.Reader, header *protocol.RequestHeader, respond func(response []byte)) {
+	log.Info("Handling JoinGroup request", "correlationID", header.CorrelationID)
+	request := &messages.JoinGroupRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode join group request", "error", err)
+		respond(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+	log.Info("Received JoinGroup request", "groupID", request.GroupId, "memberID", request.MemberId, "protocolType", request.ProtocolType)
+
+	clientID := ""
+	if header.ClientID != nil {
+		clientID = *header.ClientID
+	}
+	protocols := make([]coordinator.Protocol, len(request.Protocols))
+	for i, p := range request.Protocols {
+		protocols[i] = coordinator.Protocol{Name: p.Name, Metadata: p.Metadata}
+	}
+	rebalanceTimeoutMs := request.RebalanceTimeoutMs
+	if header.ApiVersion < 1 {
+		// Version 0 has no rebalance timeout; the session timeout is used for both.
+		rebalanceTimeoutMs = request.SessionTimeoutMs
+	}
+	results := h.coordinator.JoinGroup(coordinator.JoinParams{
+		GroupID:              request.GroupId,
+		MemberID:             request.MemberId,
+		GroupInstanceID:      request.GroupInstanceId,
+		ClientID:             clientID,
+		SessionTimeout:       time.Duration(request.SessionTimeoutMs) * time.Millisecond,
+		RebalanceTimeout:     time.Duration(rebalanceTimeoutMs) * time.Millisecond,
+		ProtocolType:         request.ProtocolType,
+		Protocols:            protocols,
+		RequireKnownMemberID: header.ApiVersion >= 4,
+	})
+
+	protocol.RespondAsync(log, h, header, respond, func() protocol.Message {
+		result := <-results
+		response := &messages.JoinGroupResponse{
+			ThrottleTimeMs: 0,
+			ErrorCode:      result.ErrorCode,
+			GenerationId:   result.GenerationID,
+			ProtocolType:   result.ProtocolType,
+			ProtocolName:   result.ProtocolName,
+			Leader:         result.LeaderID,
+			MemberId:       result.MemberID,
+			Members:        make([]messages.JoinGroupResponseMember, len(result.Members)),
+		}
+		for i, m := range result.Members {
+			response.Members[i] = messages.JoinGroupResponseMember{
+				MemberId:        m.MemberID,
+				GroupInstanceId: m.GroupInstanceID,
+				Metadata:        m.Metadata,
+			}
+		}
+		emptyProtocolName(response, header.ApiVersion)
+		log.Info("Sending JoinGroup response", "errorCode", response.ErrorCode, "generationID", response.GenerationId, "memberID", response.MemberId)
+		return response
+	})
+}
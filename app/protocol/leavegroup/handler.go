@@ -0,0 +1,102 @@
+package leavegroup
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 5
+)
+
+// LeaveGroupHandler implements the protocol.RequestHandler interface for LeaveGroup requests.
+type LeaveGroupHandler struct {
+	coordinator *coordinator.GroupCoordinator
+}
+
+// NewLeaveGroupHandler creates a new handler for LeaveGroup requests.
+func NewLeaveGroupHandler(groupCoordinator *coordinator.GroupCoordinator) *LeaveGroupHandler {
+	return &LeaveGroupHandler{coordinator: groupCoordinator}
+}
+
+// ApiKey returns the API key for LeaveGroup requests.
+func (h *LeaveGroupHandler) ApiKey() int16 {
+	return protocol.ApiKeyLeaveGroup
+}
+
+// MinVersion returns the lowest supported version of LeaveGroup requests.
+func (h *LeaveGroupHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of LeaveGroup requests.
+func (h *LeaveGroupHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a LeaveGroup response carrying only errorCode.
+func (h *LeaveGroupHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.LeaveGroupResponse{ErrorCode: errorCode, Members: []messages.LeaveGroupResponseMemberResponse{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the LeaveGroup request.
+func (h *LeaveGroupHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling LeaveGroup request", "correlationID", header.CorrelationID)
+	request := &messages.LeaveGroupRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode leave group request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	members := request.Members
+	if header.ApiVersion <= 2 {
+		// Versions 0-2 name the single leaving member at the top level.
+		members = []messages.LeaveGroupRequestMemberIdentity{{MemberId: request.MemberId}}
+	}
+	leaving := make([]coordinator.LeavingMember, len(members))
+	for i, m := range members {
+		leaving[i] = coordinator.LeavingMember{MemberID: m.MemberId, GroupInstanceID: m.GroupInstanceId}
+	}
+	errorCode, memberErrorCodes := h.coordinator.LeaveGroup(request.GroupId, leaving)
+
+	response := &messages.LeaveGroupResponse{
+		ThrottleTimeMs: 0,
+		ErrorCode:      errorCode,
+		Members:        make([]messages.LeaveGroupResponseMemberResponse, len(members)),
+	}
+	for i, m := range members {
+		response.Members[i] = messages.LeaveGroupResponseMemberResponse{
+			MemberId:        m.MemberId,
+			GroupInstanceId: m.GroupInstanceId,
+			ErrorCode:       memberErrorCodes[i],
+		}
+	}
+	if header.ApiVersion <= 2 && errorCode == protocol.ErrorCodeNone {
+		// Versions 0-2 leave a single member and only report a top-level error.
+		response.ErrorCode = memberErrorCodes[0]
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode leave group response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode leave group response", "error", err)
+		return
+	}
+	log.Info("Sent LeaveGroup response", "errorCode", response.ErrorCode)
+}
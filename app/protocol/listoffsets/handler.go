@@ -0,0 +1,134 @@
+package listoffsets
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+)
+
+const (
+	MinVersion int16 = 1
+	MaxVersion int16 = 8
+)
+
+// ListOffsetsHandler implements the protocol.RequestHandler interface for ListOffsets requests.
+type ListOffsetsHandler struct {
+	images *metadataimage.Manager
+	logs   *storage.LogManager
+}
+
+// NewListOffsetsHandler creates a new handler for ListOffsets requests.
+func NewListOffsetsHandler(images *metadataimage.Manager, logs *storage.LogManager) *ListOffsetsHandler {
+	return &ListOffsetsHandler{images: images, logs: logs}
+}
+
+// ApiKey returns the API key for ListOffsets requests.
+func (h *ListOffsetsHandler) ApiKey() int16 {
+	return protocol.ApiKeyListOffsets
+}
+
+// MinVersion returns the lowest supported version of ListOffsets requests.
+func (h *ListOffsetsHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of ListOffsets requests.
+func (h *ListOffsetsHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty ListOffsets response.
+func (h *ListOffsetsHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.ListOffsetsResponse{Topics: []messages.ListOffsetsResponseListOffsetsTopicResponse{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the ListOffsets request.
+func (h *ListOffsetsHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling ListOffsets request", "correlationID", header.CorrelationID)
+	request := &messages.ListOffsetsRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode list offsets request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	image := h.images.Image()
+
+	response := &messages.ListOffsetsResponse{
+		ThrottleTimeMs: 0,
+		Topics:         make([]messages.ListOffsetsResponseListOffsetsTopicResponse, len(request.Topics)),
+	}
+	for i, t := range request.Topics {
+		response.Topics[i] = messages.ListOffsetsResponseListOffsetsTopicResponse{
+			Name:       t.Name,
+			Partitions: make([]messages.ListOffsetsResponseListOffsetsPartitionResponse, len(t.Partitions)),
+		}
+		topic := image.TopicByName(t.Name)
+		for j, p := range t.Partitions {
+			response.Topics[i].Partitions[j] = h.listPartitionOffset(log, t.Name, p, topic)
+		}
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode list offsets response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode list offsets response", "error", err)
+		return
+	}
+	log.Info("Sent ListOffsets response")
+}
+
+func (h *ListOffsetsHandler) listPartitionOffset(log *slog.Logger, topicName string, p messages.ListOffsetsRequestListOffsetsPartition, topic *metadataimage.TopicImage) messages.ListOffsetsResponseListOffsetsPartitionResponse {
+	response := messages.ListOffsetsResponseListOffsetsPartitionResponse{
+		PartitionIndex: p.PartitionIndex,
+		ErrorCode:      protocol.ErrorCodeUnknownTopicOrPartition,
+		Timestamp:      -1,
+		Offset:         -1,
+		LeaderEpoch:    -1,
+	}
+	if topic == nil {
+		return response
+	}
+	partition, ok := topic.Partition(p.PartitionIndex)
+	if !ok {
+		return response
+	}
+
+	partitionLog, err := h.logs.Log(topicName, p.PartitionIndex)
+	if errors.Is(err, storage.ErrLogDeleted) {
+		// The topic was deleted after it was looked up.
+		response.ErrorCode = protocol.ErrorCodeUnknownTopicOrPartition
+		return response
+	}
+	if err != nil {
+		log.Error("failed to open topic log", "topic", topicName, "partition", p.PartitionIndex, "error", err)
+		response.ErrorCode = protocol.ErrorCodeKafkaStorageError
+		return response
+	}
+	result, err := lookupOffset(partitionLog, p.Timestamp, partition.LeaderEpoch)
+	if err != nil {
+		log.Error("failed to read topic log", "topic", topicName, "partition", p.PartitionIndex, "error", err)
+		response.ErrorCode = protocol.ErrorCodeKafkaStorageError
+		return response
+	}
+	response.ErrorCode = protocol.ErrorCodeNone
+	response.Timestamp = result.Timestamp
+	response.Offset = result.Offset
+	response.LeaderEpoch = result.LeaderEpoch
+	return response
+}
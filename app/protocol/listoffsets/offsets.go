@@ -0,0 +1,111 @@
+package listoffsets
+
+import (
+	"errors"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+)
+
+// Special timestamps used to query offsets that are not looked up by time.
+const (
+	TimestampLatest        int64 = -1
+	TimestampEarliest      int64 = -2
+	TimestampMaxTimestamp  int64 = -3
+	TimestampEarliestLocal int64 = -4
+)
+
+// timestampTypeLogAppendTime is the RecordBatch attribute bit marking batches
+// whose records all carry the broker append time (MaxTimestamp).
+const timestampTypeLogAppendTime = 0x08
+
+// offsetLookup is the answer to a ListOffsets query for a single partition.
+type offsetLookup struct {
+	Timestamp   int64
+	Offset      int64
+	LeaderEpoch int32
+}
+
+// errOffsetFound stops the log scan of a timestamp lookup.
+var errOffsetFound = errors.New("offset found")
+
+// lookupOffset resolves a ListOffsets timestamp against a partition log, using
+// the segment indexes to avoid reading the whole log. leaderEpoch is reported
+// for offsets that do not point at a record.
+func lookupOffset(partitionLog *storage.Log, timestamp int64, leaderEpoch int32) (offsetLookup, error) {
+	notFound := offsetLookup{Timestamp: -1, Offset: -1, LeaderEpoch: -1}
+	switch timestamp {
+	case TimestampEarliest, TimestampEarliestLocal:
+		start := partitionLog.LogStartOffset()
+		batches, err := partitionLog.ReadBatches(start, 1)
+		if err != nil {
+			return notFound, err
+		}
+		if len(batches) == 0 {
+			return offsetLookup{Timestamp: -1, Offset: start, LeaderEpoch: leaderEpoch}, nil
+		}
+		return offsetLookup{Timestamp: -1, Offset: batches[0].BaseOffset, LeaderEpoch: batchLeaderEpoch(batches[0], leaderEpoch)}, nil
+	case TimestampLatest:
+		return offsetLookup{Timestamp: -1, Offset: partitionLog.LogEndOffset(), LeaderEpoch: leaderEpoch}, nil
+	case TimestampMaxTimestamp:
+		maxTimestamp, batchOffset := partitionLog.MaxTimestamp()
+		if maxTimestamp < 0 {
+			return notFound, nil
+		}
+		batches, err := partitionLog.ReadBatches(batchOffset, 1)
+		if err != nil || len(batches) == 0 {
+			return notFound, err
+		}
+		batch := batches[0]
+		offset := batch.BaseOffset + int64(batch.LastOffsetDelta)
+		for _, record := range batch.Records {
+			if recordTimestamp(batch, record) == batch.MaxTimestamp {
+				offset = batch.BaseOffset + record.OffsetDelta
+				break
+			}
+		}
+		return offsetLookup{Timestamp: batch.MaxTimestamp, Offset: offset, LeaderEpoch: batchLeaderEpoch(batch, leaderEpoch)}, nil
+	}
+	if timestamp < 0 {
+		return notFound, nil
+	}
+	start, ok := partitionLog.TimestampSearchOffset(timestamp)
+	if !ok {
+		return notFound, nil
+	}
+	result := notFound
+	err := partitionLog.Scan(start, false, func(batch metadata.RecordBatch) error {
+		if batch.MaxTimestamp < timestamp {
+			return nil
+		}
+		for _, record := range batch.Records {
+			if ts := recordTimestamp(batch, record); ts >= timestamp {
+				result = offsetLookup{Timestamp: ts, Offset: batch.BaseOffset + record.OffsetDelta, LeaderEpoch: batchLeaderEpoch(batch, leaderEpoch)}
+				return errOffsetFound
+			}
+		}
+		// The records could not be inspected, so answer with the start of the batch.
+		result = offsetLookup{Timestamp: batch.MaxTimestamp, Offset: batch.BaseOffset, LeaderEpoch: batchLeaderEpoch(batch, leaderEpoch)}
+		return errOffsetFound
+	})
+	if err != nil && !errors.Is(err, errOffsetFound) {
+		return notFound, err
+	}
+	return result, nil
+}
+
+func recordTimestamp(batch metadata.RecordBatch, record metadata.Record) int64 {
+	if batch.Attributes&timestampTypeLogAppendTime != 0 {
+		return batch.MaxTimestamp
+	}
+	return batch.FirstTimestamp + record.TimestampDelta
+}
+
+// batchLeaderEpoch returns the leader epoch stamped on a batch, falling back to
+// the current leader epoch for batches written without one.
+func batchLeaderEpoch(batch metadata.RecordBatch, leaderEpoch int32) int32 {
+	if batch.PartitionLeaderEpoch < 0 {
+		return leaderEpoch
+	}
+	return batch.PartitionLeaderEpoch
+}
@@ -0,0 +1,37 @@
+package protocol
+
+import (
+	"bufio"
+	"io"
+)
+
+// Message is the body of a request or response. Encode and Decode take the
+// API version, so that one type covers every version of the API.
+type Message interface {
+	ApiKey() int16
+	Encode(w io.Writer, version int16) error
+	Decode(r *bufio.Reader, version int16) error
+	// Size returns the number of bytes Encode writes in the given version.
+	Size(version int16) int
+}
+
+// MessageSize returns the number of bytes m encodes to in the given version by
+// encoding it. Generated messages compute Size without encoding; MessageSize
+// is the reference it must agree with.
+func MessageSize(m interface {
+	Encode(w io.Writer, version int16) error
+}, version int16) int {
+	var w countingWriter
+	m.Encode(&w, version)
+	return w.n
+}
+
+// countingWriter counts the bytes written to it and drops them.
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
@@ -0,0 +1,1026 @@
+// Command gen generates the message types of package messages from Kafka's
+// JSON message specs.
+//
+// Every spec becomes one Go file with a struct per message and per nested
+// struct. The structs have Decode and Encode methods taking the message
+// version, which handle the fields present in that version, flexible
+// versions, nullable fields and tagged fields. Tagged fields the spec does not
+// know are kept in UnknownTaggedFields and encoded again. Size computes the
+// number of bytes Encode writes without encoding.
+//
+// With -checks, gen also writes a file to package protocol asserting that the
+// request and response types implement protocol.Message, since package
+// messages cannot import protocol.
+//
+// Usage:
+//
+//	go run ./gen -specs specs -out . -checks ../generated_messages.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	specsDir := flag.String("specs", "specs", "directory of the JSON message specs")
+	outDir := flag.String("out", ".", "directory to write the generated files to")
+	pkg := flag.String("package", "messages", "package name of the generated files")
+	checks := flag.String("checks", "", "file to write the protocol.Message checks to, if any")
+	flag.Parse()
+
+	paths, err := filepath.Glob(filepath.Join(*specsDir, "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	var messages []string
+	for _, path := range paths {
+		s, err := readSpec(path)
+		if err != nil {
+			log.Fatalf("failed to read %s: %v", path, err)
+		}
+		if isMessage(s) {
+			messages = append(messages, s.Name)
+		}
+		src, err := generate(*pkg, filepath.Base(path), s)
+		if err != nil {
+			log.Fatalf("failed to generate %s: %v", path, err)
+		}
+		out := filepath.Join(*outDir, snakeCase(s.Name)+".go")
+		if err := os.WriteFile(out, src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *checks != "" {
+		src, err := generateChecks(*pkg, messages)
+		if err != nil {
+			log.Fatalf("failed to generate %s: %v", *checks, err)
+		}
+		if err := os.WriteFile(*checks, src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generateChecks generates the file of package protocol asserting that the
+// types of the given messages implement protocol.Message.
+func generateChecks(pkg string, messages []string) ([]byte, error) {
+	var src bytes.Buffer
+	src.WriteString("// Code generated by gen from specs/*.json. DO NOT EDIT.\n\n")
+	src.WriteString("package protocol\n\n")
+	fmt.Fprintf(&src, "import %q\n\n", "github.com/codecrafters-io/kafka-starter-go/app/protocol/"+pkg)
+	src.WriteString("// The generated request and response types implement Message.\nvar (\n")
+	for _, name := range messages {
+		fmt.Fprintf(&src, "\t_ Message = (*%s.%s)(nil)\n", pkg, name)
+	}
+	src.WriteString(")\n")
+	return format.Source(src.Bytes())
+}
+
+// spec is a message spec, e.g. clients/src/main/resources/common/message/FetchRequest.json.
+type spec struct {
+	ApiKey           *int16        `json:"apiKey"`
+	Type             string        `json:"type"`
+	Name             string        `json:"name"`
+	ValidVersions    string        `json:"validVersions"`
+	FlexibleVersions string        `json:"flexibleVersions"`
+	Fields           []*field      `json:"fields"`
+	CommonStructs    []*structSpec `json:"commonStructs"`
+}
+
+type structSpec struct {
+	Name     string   `json:"name"`
+	Versions string   `json:"versions"`
+	Fields   []*field `json:"fields"`
+}
+
+type field struct {
+	Name             string   `json:"name"`
+	Type             string   `json:"type"`
+	Versions         string   `json:"versions"`
+	NullableVersions string   `json:"nullableVersions"`
+	TaggedVersions   string   `json:"taggedVersions"`
+	FlexibleVersions string   `json:"flexibleVersions"`
+	Tag              *uint64  `json:"tag"`
+	Default          any      `json:"default"`
+	About            string   `json:"about"`
+	Fields           []*field `json:"fields"`
+}
+
+var commentLine = regexp.MustCompile(`(?m)^\s*//.*$`)
+
+// readSpec reads a spec, dropping the comment lines Kafka's specs contain.
+func readSpec(path string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := json.Unmarshal(commentLine.ReplaceAll(data, nil), &s); err != nil {
+		return nil, err
+	}
+	exportFieldNames(s.Fields)
+	for _, cs := range s.CommonStructs {
+		exportFieldNames(cs.Fields)
+	}
+	return &s, nil
+}
+
+// exportFieldNames capitalizes field names, since a few specs spell them in
+// lower camel case (e.g. groupId in OffsetFetchRequest).
+func exportFieldNames(fields []*field) {
+	for _, f := range fields {
+		f.Name = strings.ToUpper(f.Name[:1]) + f.Name[1:]
+		exportFieldNames(f.Fields)
+	}
+}
+
+// versionRange is an inclusive range of versions. A range with lo > hi is empty.
+type versionRange struct {
+	lo, hi int
+}
+
+// parseVersions parses a version range such as "3+", "0-12", "7" or "none".
+func parseVersions(s string) (versionRange, error) {
+	switch {
+	case s == "" || s == "none":
+		return versionRange{lo: 1, hi: 0}, nil
+	case strings.HasSuffix(s, "+"):
+		lo, err := strconv.Atoi(strings.TrimSuffix(s, "+"))
+		return versionRange{lo: lo, hi: math.MaxInt16}, err
+	case strings.Contains(s, "-"):
+		lo, hi, _ := strings.Cut(s, "-")
+		l, err := strconv.Atoi(lo)
+		if err != nil {
+			return versionRange{}, err
+		}
+		h, err := strconv.Atoi(hi)
+		return versionRange{lo: l, hi: h}, err
+	default:
+		v, err := strconv.Atoi(s)
+		return versionRange{lo: v, hi: v}, err
+	}
+}
+
+func mustParseVersions(s string) versionRange {
+	r, err := parseVersions(s)
+	if err != nil {
+		log.Fatalf("invalid versions %q: %v", s, err)
+	}
+	return r
+}
+
+func (r versionRange) empty() bool { return r.lo > r.hi }
+
+func intersect(a, b versionRange) versionRange {
+	return versionRange{lo: max(a.lo, b.lo), hi: min(a.hi, b.hi)}
+}
+
+// generator holds the state of the generation of one spec.
+type generator struct {
+	spec     *spec
+	valid    versionRange
+	flexible versionRange
+	// structs are the nested struct definitions by spec name.
+	structs map[string][]*field
+	// structVersions are the versions the nested structs are used in.
+	structVersions map[string]versionRange
+	// needsIsDefault are the nested structs used as tagged fields, which need an isDefault method.
+	needsIsDefault map[string]bool
+	imports        map[string]bool
+	out            *bytes.Buffer
+}
+
+func generate(pkg, file string, s *spec) ([]byte, error) {
+	g := &generator{
+		spec:           s,
+		valid:          mustParseVersions(s.ValidVersions),
+		flexible:       mustParseVersions(s.FlexibleVersions),
+		structs:        make(map[string][]*field),
+		structVersions: make(map[string]versionRange),
+		needsIsDefault: make(map[string]bool),
+		imports:        map[string]bool{"fmt": true, "io": true, "bufio": true},
+	}
+	for _, cs := range s.CommonStructs {
+		g.structs[cs.Name] = cs.Fields
+	}
+	g.collectStructs(s.Fields, g.valid)
+
+	g.out = &bytes.Buffer{}
+	g.genStruct(s.Name, s.Fields, true)
+	valid := g.valid
+	for _, name := range g.structOrder(s.Fields) {
+		// Conditions in nested structs only need to tell apart the versions the struct is used in.
+		g.valid = g.structVersions[name]
+		g.genStruct(name, g.structs[name], false)
+	}
+	g.valid = valid
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by gen from specs/%s. DO NOT EDIT.\n\n", file)
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", pkg)
+	var std, ext []string
+	for imp := range g.imports {
+		if strings.Contains(imp, ".") {
+			ext = append(ext, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(ext)
+	for _, imp := range std {
+		fmt.Fprintf(&src, "\t%q\n", imp)
+	}
+	src.WriteString("\n")
+	for _, imp := range ext {
+		fmt.Fprintf(&src, "\t%q\n", imp)
+	}
+	src.WriteString(")\n\n")
+	src.Write(g.out.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, src.Bytes())
+	}
+	return formatted, nil
+}
+
+// collectStructs records the struct definitions nested in fields, which are
+// used in the versions in valid, and the structs that need an isDefault method.
+func (g *generator) collectStructs(fields []*field, valid versionRange) {
+	for _, f := range fields {
+		elem := strings.TrimPrefix(f.Type, "[]")
+		if len(f.Fields) > 0 {
+			g.structs[elem] = f.Fields
+		}
+	}
+	for _, f := range fields {
+		elem := strings.TrimPrefix(f.Type, "[]")
+		if !g.isStruct(elem) {
+			continue
+		}
+		used := intersect(valid, mustParseVersions(f.Versions))
+		if f.Tag != nil {
+			used = intersect(used, mustParseVersions(f.TaggedVersions))
+		}
+		if prev, ok := g.structVersions[elem]; ok {
+			used = versionRange{lo: min(prev.lo, used.lo), hi: max(prev.hi, used.hi)}
+		}
+		g.structVersions[elem] = used
+		g.collectStructs(g.structs[elem], used)
+	}
+	for _, f := range fields {
+		if f.Tag != nil && !strings.HasPrefix(f.Type, "[]") && g.isStruct(f.Type) && mustParseVersions(f.NullableVersions).empty() {
+			g.markIsDefault(f.Type)
+		}
+	}
+}
+
+func (g *generator) markIsDefault(name string) {
+	g.needsIsDefault[name] = true
+	for _, f := range g.structs[name] {
+		if !strings.HasPrefix(f.Type, "[]") && g.isStruct(f.Type) && mustParseVersions(f.NullableVersions).empty() {
+			g.markIsDefault(f.Type)
+		}
+	}
+}
+
+// structOrder returns the nested structs in the order they are first used.
+func (g *generator) structOrder(fields []*field) []string {
+	var order []string
+	var walk func(fields []*field)
+	walk = func(fields []*field) {
+		for _, f := range fields {
+			elem := strings.TrimPrefix(f.Type, "[]")
+			if g.isStruct(elem) && !slices.Contains(order, elem) {
+				order = append(order, elem)
+				walk(g.structs[elem])
+			}
+		}
+	}
+	walk(fields)
+	return order
+}
+
+func (g *generator) isStruct(typ string) bool {
+	_, ok := g.structs[typ]
+	return ok
+}
+
+// goStructName returns the Go name of a struct, prefixed with the message
+// name to keep the structs of different messages apart.
+func (g *generator) goStructName(name string) string {
+	if name == g.spec.Name || strings.HasPrefix(name, g.spec.Name) {
+		return name
+	}
+	return g.spec.Name + name
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(g.out, format, args...)
+}
+
+// cond returns the Go condition for a version being in r, given that it is a
+// valid version of the message. It returns "true" or "false" when the
+// condition does not depend on the version.
+func (g *generator) cond(r versionRange) string {
+	if r.empty() || r.hi < g.valid.lo || r.lo > g.valid.hi {
+		return "false"
+	}
+	var parts []string
+	if r.lo > g.valid.lo {
+		parts = append(parts, fmt.Sprintf("version >= %d", r.lo))
+	}
+	if r.hi < g.valid.hi {
+		parts = append(parts, fmt.Sprintf("version <= %d", r.hi))
+	}
+	if len(parts) == 0 {
+		return "true"
+	}
+	return strings.Join(parts, " && ")
+}
+
+// flexibleExpr returns the expression telling whether field f uses compact encodings.
+func (g *generator) flexibleExpr(f *field) string {
+	if f.FlexibleVersions != "" {
+		return g.cond(mustParseVersions(f.FlexibleVersions))
+	}
+	switch c := g.cond(g.flexible); c {
+	case "true", "false":
+		return c
+	default:
+		return "flexible"
+	}
+}
+
+// goType returns the Go type of field f.
+func (g *generator) goType(f *field) string {
+	nullable := !mustParseVersions(f.NullableVersions).empty()
+	if elem, ok := strings.CutPrefix(f.Type, "[]"); ok {
+		return "[]" + g.elemGoType(elem)
+	}
+	switch {
+	case f.Type == "string" && nullable:
+		return "*string"
+	case g.isStruct(f.Type) && nullable:
+		return "*" + g.goStructName(f.Type)
+	}
+	return g.elemGoType(f.Type)
+}
+
+func (g *generator) elemGoType(typ string) string {
+	switch typ {
+	case "bool", "int8", "int16", "uint16", "int32", "uint32", "int64", "float64", "string":
+		return typ
+	case "bytes", "records":
+		return "[]byte"
+	case "uuid":
+		g.imports["github.com/google/uuid"] = true
+		return "uuid.UUID"
+	}
+	if g.isStruct(typ) {
+		return g.goStructName(typ)
+	}
+	log.Fatalf("%s: unknown type %q", g.spec.Name, typ)
+	return ""
+}
+
+// hasTaggedFields reports whether the structs of the message have a tagged field section.
+func (g *generator) hasTaggedFields() bool {
+	return g.cond(g.flexible) != "false"
+}
+
+func (g *generator) genStruct(name string, fields []*field, top bool) {
+	goName := g.goStructName(name)
+	if top {
+		g.printf("// %s is generated from the %s spec (versions %s, flexible versions %s).\n",
+			goName, g.spec.Name, g.spec.ValidVersions, orNone(g.spec.FlexibleVersions))
+	} else {
+		g.printf("// %s is a struct of %s.\n", goName, g.spec.Name)
+	}
+	g.printf("type %s struct {\n", goName)
+	for _, f := range fields {
+		if f.About != "" {
+			g.printf("\t// %s\n", f.About)
+		}
+		g.printf("\t%s %s\n", f.Name, g.goType(f))
+	}
+	if g.hasTaggedFields() {
+		g.imports["github.com/codecrafters-io/kafka-starter-go/app/decoder"] = true
+		g.printf("\t// UnknownTaggedFields are the tagged fields the spec does not define, kept so that they are encoded again.\n")
+		g.printf("\tUnknownTaggedFields decoder.TaggedFields\n")
+	}
+	g.printf("}\n\n")
+
+	if top && isMessage(g.spec) {
+		g.genApiKey(goName)
+	}
+	if top && g.spec.Type == "metadata" {
+		g.genRecordVersions(goName)
+	}
+	g.genSetDefaults(goName, fields)
+	g.genDecode(goName, fields, top)
+	g.genEncode(goName, fields, top)
+	g.genSize(goName, fields)
+	if g.needsIsDefault[name] {
+		g.genIsDefault(goName, fields)
+	}
+}
+
+// isMessage reports whether spec s is a request or response, whose type
+// implements protocol.Message.
+func isMessage(s *spec) bool {
+	return (s.Type == "request" || s.Type == "response") && s.ApiKey != nil
+}
+
+// genApiKey emits the ApiKey method of protocol.Message.
+func (g *generator) genApiKey(goName string) {
+	g.printf("// ApiKey returns the API key of %s.\n", g.spec.Name)
+	g.printf("func (s *%s) ApiKey() int16 {\n", goName)
+	g.printf("\treturn %d\n", *g.spec.ApiKey)
+	g.printf("}\n\n")
+}
+
+// genRecordVersions emits the LowestSupportedVersion and
+// HighestSupportedVersion methods of metadata records, which let readers of the
+// metadata log skip record versions they cannot decode.
+func (g *generator) genRecordVersions(goName string) {
+	g.printf("// LowestSupportedVersion returns the lowest version of %s.\n", g.spec.Name)
+	g.printf("func (s *%s) LowestSupportedVersion() int16 {\n", goName)
+	g.printf("\treturn %d\n", g.valid.lo)
+	g.printf("}\n\n")
+	g.printf("// HighestSupportedVersion returns the highest version of %s.\n", g.spec.Name)
+	g.printf("func (s *%s) HighestSupportedVersion() int16 {\n", goName)
+	g.printf("\treturn %d\n", g.valid.hi)
+	g.printf("}\n\n")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// defaultLiteral returns the Go literal of the default value of f, or "" when
+// the default is the zero value.
+func (g *generator) defaultLiteral(f *field) string {
+	if f.Default == nil {
+		return ""
+	}
+	var def string
+	switch d := f.Default.(type) {
+	case string:
+		def = d
+	case bool:
+		def = strconv.FormatBool(d)
+	case float64:
+		def = strconv.FormatFloat(d, 'f', -1, 64)
+	}
+	switch {
+	case def == "null", strings.HasPrefix(f.Type, "[]"), g.isStruct(f.Type):
+		return ""
+	case f.Type == "string":
+		if def == "" {
+			return ""
+		}
+		if g.goType(f) == "*string" {
+			return fmt.Sprintf("stringPtr(%q)", def)
+		}
+		return strconv.Quote(def)
+	case f.Type == "bool":
+		if def == "false" {
+			return ""
+		}
+		return def
+	case f.Type == "uuid", f.Type == "bytes", f.Type == "records":
+		return ""
+	}
+	if n, err := strconv.ParseInt(def, 0, 64); err == nil && n == 0 {
+		return ""
+	}
+	return def
+}
+
+func (g *generator) genSetDefaults(goName string, fields []*field) {
+	g.printf("// SetDefaults resets s to the default value of every field.\n")
+	g.printf("func (s *%s) SetDefaults() {\n", goName)
+	g.printf("\t*s = %s{}\n", goName)
+	for _, f := range fields {
+		if def := g.defaultLiteral(f); def != "" {
+			g.printf("\ts.%s = %s\n", f.Name, def)
+		} else if g.isStruct(f.Type) && mustParseVersions(f.NullableVersions).empty() {
+			g.printf("\ts.%s.SetDefaults()\n", f.Name)
+		}
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) genDecode(goName string, fields []*field, top bool) {
+	out := g.out
+	g.out = &bytes.Buffer{}
+
+	if top {
+		g.printf("\tif version < %d || version > %d {\n", g.valid.lo, g.valid.hi)
+		g.printf("\t\treturn fmt.Errorf(\"unsupported %s version %%d\", version)\n", g.spec.Name)
+		g.printf("\t}\n")
+	}
+	g.printf("\ts.SetDefaults()\n")
+	var tagged []*field
+	for _, f := range fields {
+		if f.Tag != nil {
+			tagged = append(tagged, f)
+			continue
+		}
+		g.inVersions(f, func(indent string) {
+			g.decodeField(indent, f, "s."+f.Name, "r", g.flexibleExpr(f))
+		})
+	}
+	if g.hasTaggedFields() {
+		g.inRange(g.flexible, "\t", func(indent string) {
+			g.printf("%ss.UnknownTaggedFields, err = decoder.DecodeTaggedFields(r)\n", indent)
+			g.printErrCheck(indent, "failed to decode tagged fields")
+			for _, f := range tagged {
+				g.inRange(taggedVersions(f), indent, func(indent string) {
+					g.printf("%sif tr, ok := s.UnknownTaggedFields.Take(%d); ok {\n", indent, *f.Tag)
+					g.decodeField(indent+"\t", f, "s."+f.Name, "tr", "true")
+					g.printf("%s}\n", indent)
+				})
+			}
+		})
+	}
+	g.printf("\treturn nil\n")
+
+	code := g.out.String()
+	g.out = out
+	g.printf("// Decode decodes s in the given version of %s.\n", g.spec.Name)
+	g.printf("func (s *%s) Decode(r *bufio.Reader, version int16) error {\n", goName)
+	g.printLocals(code)
+	g.out.WriteString(code)
+	g.printf("}\n\n")
+}
+
+func (g *generator) genEncode(goName string, fields []*field, top bool) {
+	out := g.out
+	g.out = &bytes.Buffer{}
+
+	if top {
+		g.printf("\tif version < %d || version > %d {\n", g.valid.lo, g.valid.hi)
+		g.printf("\t\treturn fmt.Errorf(\"unsupported %s version %%d\", version)\n", g.spec.Name)
+		g.printf("\t}\n")
+	}
+	var tagged []*field
+	for _, f := range fields {
+		if f.Tag != nil {
+			tagged = append(tagged, f)
+			continue
+		}
+		g.inVersions(f, func(indent string) {
+			g.encodeField(indent, f, "s."+f.Name, "w", g.flexibleExpr(f))
+		})
+	}
+	if g.hasTaggedFields() {
+		g.inRange(g.flexible, "\t", func(indent string) {
+			if len(tagged) == 0 {
+				g.printf("%serr = encoder.EncodeTaggedFields(w, s.UnknownTaggedFields)\n", indent)
+			} else {
+				g.imports["slices"] = true
+				g.imports["bytes"] = true
+				g.printf("%sfields := slices.Clone(s.UnknownTaggedFields)\n", indent)
+				for _, f := range tagged {
+					c := g.cond(taggedVersions(f))
+					if c == "false" {
+						continue
+					}
+					nd := g.notDefault(f, "s."+f.Name)
+					if c != "true" {
+						nd = c + " && " + nd
+					}
+					g.printf("%sif %s {\n", indent, nd)
+					g.printf("%s\tvar buf bytes.Buffer\n", indent)
+					g.narrow(taggedVersions(f), func() {
+						g.encodeField(indent+"\t", f, "s."+f.Name, "&buf", "true")
+					})
+					g.printf("%s\tfields.Set(%d, buf.Bytes())\n", indent, *f.Tag)
+					g.printf("%s}\n", indent)
+				}
+				g.printf("%serr = encoder.EncodeTaggedFields(w, fields)\n", indent)
+			}
+			g.printErrCheck(indent, "failed to encode tagged fields")
+		})
+	}
+	g.printf("\treturn nil\n")
+
+	code := g.out.String()
+	g.out = out
+	g.imports["github.com/codecrafters-io/kafka-starter-go/app/encoder"] = true
+	g.printf("// Encode encodes s in the given version of %s.\n", g.spec.Name)
+	g.printf("func (s *%s) Encode(w io.Writer, version int16) error {\n", goName)
+	g.printLocals(code)
+	g.out.WriteString(code)
+	g.printf("}\n\n")
+}
+
+func (g *generator) genSize(goName string, fields []*field) {
+	out := g.out
+	g.out = &bytes.Buffer{}
+
+	var tagged []*field
+	for _, f := range fields {
+		if f.Tag != nil {
+			tagged = append(tagged, f)
+			continue
+		}
+		g.inVersions(f, func(indent string) {
+			g.sizeField(indent, f, "s."+f.Name, "size", g.flexibleExpr(f))
+		})
+	}
+	if g.hasTaggedFields() {
+		g.inRange(g.flexible, "\t", func(indent string) {
+			if len(tagged) == 0 {
+				g.printf("%ssize += taggedFieldsSize(s.UnknownTaggedFields, nil)\n", indent)
+				return
+			}
+			g.printf("%svar known []taggedSize\n", indent)
+			for _, f := range tagged {
+				c := g.cond(taggedVersions(f))
+				if c == "false" {
+					continue
+				}
+				nd := g.notDefault(f, "s."+f.Name)
+				if c != "true" {
+					nd = c + " && " + nd
+				}
+				g.printf("%sif %s {\n", indent, nd)
+				g.printf("%s\tn := 0\n", indent)
+				g.narrow(taggedVersions(f), func() {
+					g.sizeField(indent+"\t", f, "s."+f.Name, "n", "true")
+				})
+				g.printf("%s\tknown = append(known, taggedSize{tag: %d, size: n})\n", indent, *f.Tag)
+				g.printf("%s}\n", indent)
+			}
+			g.printf("%ssize += taggedFieldsSize(s.UnknownTaggedFields, known)\n", indent)
+		})
+	}
+	g.printf("\treturn size\n")
+
+	code := g.out.String()
+	g.out = out
+	g.printf("// Size returns the number of bytes s encodes to in the given version.\n")
+	g.printf("func (s *%s) Size(version int16) int {\n", goName)
+	g.printLocals(code)
+	g.printf("\tsize := 0\n")
+	g.out.WriteString(code)
+	g.printf("}\n\n")
+}
+
+// printLocals declares the local variables the code of a method uses.
+func (g *generator) printLocals(code string) {
+	if strings.Contains(code, "flexible") {
+		g.printf("\tflexible := %s\n", g.cond(g.flexible))
+	}
+	if strings.Contains(code, "err =") {
+		g.printf("\tvar err error\n")
+	}
+}
+
+func (g *generator) genIsDefault(goName string, fields []*field) {
+	g.printf("// isDefault reports whether every field of s has its default value.\n")
+	g.printf("func (s *%s) isDefault() bool {\n", goName)
+	g.printf("\treturn ")
+	for _, f := range fields {
+		g.printf("!(%s) &&\n\t\t", g.notDefault(f, "s."+f.Name))
+	}
+	if g.hasTaggedFields() {
+		g.printf("len(s.UnknownTaggedFields) == 0\n")
+	} else {
+		g.printf("true\n")
+	}
+	g.printf("}\n\n")
+}
+
+// notDefault returns the condition for the value src of field f differing from its default.
+func (g *generator) notDefault(f *field, src string) string {
+	nullable := !mustParseVersions(f.NullableVersions).empty()
+	def := g.defaultLiteral(f)
+	switch {
+	case strings.HasPrefix(f.Type, "[]"), f.Type == "bytes", f.Type == "records":
+		if nullable {
+			return src + " != nil"
+		}
+		return "len(" + src + ") > 0"
+	case g.isStruct(f.Type):
+		if nullable {
+			return src + " != nil"
+		}
+		return "!" + src + ".isDefault()"
+	case f.Type == "uuid":
+		return src + " != uuid.Nil"
+	case f.Type == "string" && nullable:
+		if def == "" {
+			return src + " != nil"
+		}
+		return fmt.Sprintf("(%s == nil || *%s != %s)", src, src, strings.TrimSuffix(strings.TrimPrefix(def, "stringPtr("), ")"))
+	case f.Type == "string":
+		if def == "" {
+			def = `""`
+		}
+		return src + " != " + def
+	case f.Type == "bool":
+		if def == "" {
+			def = "false"
+		}
+		return src + " != " + def
+	}
+	if def == "" {
+		def = "0"
+	}
+	return src + " != " + def
+}
+
+// inVersions runs emit inside a condition on the versions of field f.
+func (g *generator) inVersions(f *field, emit func(indent string)) {
+	g.inRange(mustParseVersions(f.Versions), "\t", emit)
+}
+
+// inRange runs emit inside a condition on the version being in r. The
+// conditions emit makes only tell apart the versions in r.
+func (g *generator) inRange(r versionRange, indent string, emit func(indent string)) {
+	c := g.cond(r)
+	g.narrow(r, func() {
+		g.inCond(c, indent, emit)
+	})
+}
+
+// narrow runs fn with the versions conditions tell apart narrowed to r.
+func (g *generator) narrow(r versionRange, fn func()) {
+	valid := g.valid
+	g.valid = intersect(valid, r)
+	fn()
+	g.valid = valid
+}
+
+// taggedVersions returns the versions in which field f is a tagged field.
+func taggedVersions(f *field) versionRange {
+	return intersect(mustParseVersions(f.Versions), mustParseVersions(f.TaggedVersions))
+}
+
+func (g *generator) inCond(c, indent string, emit func(indent string)) {
+	switch c {
+	case "false":
+	case "true":
+		emit(indent)
+	default:
+		g.printf("%sif %s {\n", indent, c)
+		emit(indent + "\t")
+		g.printf("%s}\n", indent)
+	}
+}
+
+func (g *generator) printErrCheck(indent, msg string) {
+	g.printf("%sif err != nil {\n", indent)
+	g.printf("%s\treturn fmt.Errorf(\"%s: %%w\", err)\n", indent, msg)
+	g.printf("%s}\n", indent)
+}
+
+// decodeField emits the decoding of field f from reader r into dst.
+func (g *generator) decodeField(indent string, f *field, dst, r, flex string) {
+	what := words(f.Name)
+	nullable := g.cond(mustParseVersions(f.NullableVersions))
+	if elem, ok := strings.CutPrefix(f.Type, "[]"); ok {
+		g.printf("%s{\n", indent)
+		g.printf("%s\tn, err := decoder.DecodeFlexArrayLength(%s, %s)\n", indent, r, flex)
+		g.printErrCheck(indent+"\t", "failed to decode "+what+" length")
+		g.printf("%s\tif n < 0 {\n", indent)
+		g.printf("%s\t\t%s = nil\n", indent, dst)
+		g.printf("%s\t} else {\n", indent)
+		g.printf("%s\t\t%s = make([]%s, n)\n", indent, dst, g.elemGoType(elem))
+		g.printf("%s\t\tfor i := range %s {\n", indent, dst)
+		g.decodeValue(indent+"\t\t\t", elem, "false", dst+"[i]", r, flex, what)
+		g.printf("%s\t\t}\n", indent)
+		g.printf("%s\t}\n", indent)
+		g.printf("%s}\n", indent)
+		return
+	}
+	g.decodeValue(indent, f.Type, nullable, dst, r, flex, what)
+}
+
+// decodeValue emits the decoding of a single value of type typ from reader r into dst.
+func (g *generator) decodeValue(indent, typ, nullable, dst, r, flex, what string) {
+	g.imports["github.com/codecrafters-io/kafka-starter-go/app/decoder"] = true
+	switch typ {
+	case "string":
+		switch nullable {
+		case "false":
+			g.printf("%s%s, err = decoder.DecodeFlexString(%s, %s)\n", indent, dst, r, flex)
+		default:
+			// Non-nullable versions never send null, so the nullable decoding reads them too.
+			g.printf("%s%s, err = decoder.DecodeFlexNullableString(%s, %s)\n", indent, dst, r, flex)
+		}
+	case "bytes", "records":
+		g.printf("%s%s, err = decoder.DecodeFlexBytes(%s, %s)\n", indent, dst, r, flex)
+	case "bool", "int8", "int16", "uint16", "int32", "uint32", "int64", "float64", "uuid":
+		g.printf("%s%s, err = decoder.Decode%s(%s)\n", indent, dst, primitiveName(typ), r)
+	default:
+		goName := g.goStructName(typ)
+		if nullable == "false" {
+			g.printf("%serr = %s.Decode(%s, version)\n", indent, dst, r)
+			break
+		}
+		g.printf("%s{\n", indent)
+		g.printf("%s\tvar present int8 = 1\n", indent)
+		g.inCond(nullable, indent+"\t", func(in string) {
+			g.printf("%spresent, err = decoder.DecodeInt8(%s)\n", in, r)
+			g.printErrCheck(in, "failed to decode "+what)
+		})
+		g.printf("%s\tif present < 0 {\n", indent)
+		g.printf("%s\t\t%s = nil\n", indent, dst)
+		g.printf("%s\t} else {\n", indent)
+		g.printf("%s\t\t%s = &%s{}\n", indent, dst, goName)
+		g.printf("%s\t\terr = %s.Decode(%s, version)\n", indent, dst, r)
+		g.printErrCheck(indent+"\t\t", "failed to decode "+what)
+		g.printf("%s\t}\n", indent)
+		g.printf("%s}\n", indent)
+		return
+	}
+	g.printErrCheck(indent, "failed to decode "+what)
+}
+
+// encodeField emits the encoding of field f from src to writer w.
+func (g *generator) encodeField(indent string, f *field, src, w, flex string) {
+	what := words(f.Name)
+	nullable := g.cond(mustParseVersions(f.NullableVersions))
+	if elem, ok := strings.CutPrefix(f.Type, "[]"); ok {
+		length := "len(" + src + ")"
+		if nullable != "false" {
+			length = fmt.Sprintf("arrayLength(%s, %s)", src, nullable)
+		}
+		g.printf("%serr = encoder.EncodeFlexArrayLength(%s, %s, %s)\n", indent, w, length, flex)
+		g.printErrCheck(indent, "failed to encode "+what+" length")
+		g.printf("%sfor i := range %s {\n", indent, src)
+		g.encodeValue(indent+"\t", elem, "false", src+"[i]", w, flex, what)
+		g.printf("%s}\n", indent)
+		return
+	}
+	g.encodeValue(indent, f.Type, nullable, src, w, flex, what)
+}
+
+// encodeValue emits the encoding of a single value of type typ from src to writer w.
+func (g *generator) encodeValue(indent, typ, nullable, src, w, flex, what string) {
+	switch typ {
+	case "string":
+		switch nullable {
+		case "false":
+			g.printf("%serr = encoder.EncodeFlexString(%s, %s, %s)\n", indent, w, src, flex)
+		case "true":
+			g.printf("%serr = encoder.EncodeFlexNullableString(%s, %s, %s)\n", indent, w, src, flex)
+		default:
+			g.printf("%sif %s == nil && !(%s) {\n", indent, src, nullable)
+			g.printf("%s\treturn fmt.Errorf(\"non-nullable field %s was serialized as null\")\n", indent, what)
+			g.printf("%s}\n", indent)
+			g.printf("%serr = encoder.EncodeFlexNullableString(%s, %s, %s)\n", indent, w, src, flex)
+		}
+	case "bytes", "records":
+		if nullable == "false" {
+			g.printf("%serr = encoder.EncodeFlexBytes(%s, nonNilBytes(%s), %s)\n", indent, w, src, flex)
+		} else {
+			g.printf("%serr = encoder.EncodeFlexBytes(%s, %s, %s)\n", indent, w, src, flex)
+		}
+	case "bool", "int8", "int16", "uint16", "int32", "uint32", "int64", "float64", "uuid":
+		g.printf("%serr = encoder.Encode%s(%s, %s)\n", indent, primitiveName(typ), w, src)
+	default:
+		if nullable == "false" {
+			g.printf("%serr = %s.Encode(%s, version)\n", indent, src, w)
+			break
+		}
+		g.printf("%sif %s == nil {\n", indent, src)
+		g.inCond(nullable, indent+"\t", func(in string) {
+			g.printf("%serr = encoder.EncodeInt8(%s, -1)\n", in, w)
+		})
+		if nullable != "true" {
+			g.printf("%s\tif !(%s) {\n", indent, nullable)
+			g.printf("%s\t\treturn fmt.Errorf(\"non-nullable field %s was serialized as null\")\n", indent, what)
+			g.printf("%s\t}\n", indent)
+		}
+		g.printf("%s} else {\n", indent)
+		g.inCond(nullable, indent+"\t", func(in string) {
+			g.printf("%serr = encoder.EncodeInt8(%s, 1)\n", in, w)
+			g.printErrCheck(in, "failed to encode "+what)
+		})
+		g.printf("%s\terr = %s.Encode(%s, version)\n", indent, src, w)
+		g.printf("%s}\n", indent)
+	}
+	g.printErrCheck(indent, "failed to encode "+what)
+}
+
+// sizeField emits adding the encoded size of field f from src to dst.
+func (g *generator) sizeField(indent string, f *field, src, dst, flex string) {
+	nullable := g.cond(mustParseVersions(f.NullableVersions))
+	if elem, ok := strings.CutPrefix(f.Type, "[]"); ok {
+		length := "len(" + src + ")"
+		if nullable != "false" {
+			length = fmt.Sprintf("arrayLength(%s, %s)", src, nullable)
+		}
+		g.printf("%s%s += arrayLengthSize(%s, %s)\n", indent, dst, length, flex)
+		if n := fixedSize(elem); n > 0 {
+			g.printf("%s%s += %d * len(%s)\n", indent, dst, n, src)
+			return
+		}
+		g.printf("%sfor i := range %s {\n", indent, src)
+		g.sizeValue(indent+"\t", elem, "false", src+"[i]", dst, flex)
+		g.printf("%s}\n", indent)
+		return
+	}
+	g.sizeValue(indent, f.Type, nullable, src, dst, flex)
+}
+
+// sizeValue emits adding the encoded size of a single value of type typ from src to dst.
+func (g *generator) sizeValue(indent, typ, nullable, src, dst, flex string) {
+	if n := fixedSize(typ); n > 0 {
+		g.printf("%s%s += %d\n", indent, dst, n)
+		return
+	}
+	switch typ {
+	case "string":
+		if nullable == "false" {
+			g.printf("%s%s += stringSize(%s, %s)\n", indent, dst, src, flex)
+		} else {
+			g.printf("%s%s += nullableStringSize(%s, %s)\n", indent, dst, src, flex)
+		}
+	case "bytes", "records":
+		if nullable == "false" {
+			g.printf("%s%s += bytesSize(nonNilBytes(%s), %s)\n", indent, dst, src, flex)
+		} else {
+			g.printf("%s%s += bytesSize(%s, %s)\n", indent, dst, src, flex)
+		}
+	default:
+		if nullable == "false" {
+			g.printf("%s%s += %s.Size(version)\n", indent, dst, src)
+			return
+		}
+		// The presence byte.
+		g.inCond(nullable, indent, func(in string) {
+			g.printf("%s%s++\n", in, dst)
+		})
+		g.printf("%sif %s != nil {\n", indent, src)
+		g.printf("%s\t%s += %s.Size(version)\n", indent, dst, src)
+		g.printf("%s}\n", indent)
+	}
+}
+
+// fixedSize returns the encoded size of a fixed size type, or 0 for other types.
+func fixedSize(typ string) int {
+	switch typ {
+	case "bool", "int8":
+		return 1
+	case "int16", "uint16":
+		return 2
+	case "int32", "uint32":
+		return 4
+	case "int64", "float64":
+		return 8
+	case "uuid":
+		return 16
+	}
+	return 0
+}
+
+// primitiveName returns the suffix of the typed decoder and encoder functions
+// of a primitive type, e.g. "Int32" for decoder.DecodeInt32.
+func primitiveName(typ string) string {
+	if typ == "uuid" {
+		return "UUID"
+	}
+	return strings.ToUpper(typ[:1]) + typ[1:]
+}
+
+// words turns a field name into lower case words for error messages, e.g.
+// "TopicId" into "topic id".
+func words(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// snakeCase turns a message name into a file name, e.g. "FetchRequest" into "fetch_request".
+func snakeCase(name string) string {
+	return strings.ReplaceAll(words(name), " ", "_")
+}
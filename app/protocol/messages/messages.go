@@ -0,0 +1,113 @@
+// Package messages holds Kafka message types generated from the JSON message
+// specs in specs/, which are copied from Kafka's
+// clients/src/main/resources/common/message and
+// metadata/src/main/resources/common/metadata directories.
+//
+// To add a message, copy its spec into specs/ and run go generate.
+package messages
+
+import (
+	"slices"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
+)
+
+//go:generate go run ./gen -specs specs -out . -checks ../generated_messages.go
+
+// uvarintSize returns the number of bytes v takes as an unsigned varint.
+func uvarintSize(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+// stringSize returns the encoded size of a string.
+func stringSize(s string, flexible bool) int {
+	if flexible {
+		return uvarintSize(uint64(len(s))+1) + len(s)
+	}
+	return 2 + len(s)
+}
+
+// nullableStringSize returns the encoded size of a nullable string.
+func nullableStringSize(s *string, flexible bool) int {
+	switch {
+	case s != nil:
+		return stringSize(*s, flexible)
+	case flexible:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// bytesSize returns the encoded size of a byte slice, which is null when nil.
+func bytesSize(b []byte, flexible bool) int {
+	switch {
+	case flexible && b == nil:
+		return 1
+	case flexible:
+		return uvarintSize(uint64(len(b))+1) + len(b)
+	default:
+		return 4 + len(b)
+	}
+}
+
+// arrayLengthSize returns the encoded size of an array length, where a
+// negative length is a null array.
+func arrayLengthSize(length int, flexible bool) int {
+	if !flexible {
+		return 4
+	}
+	return uvarintSize(uint64(max(length, -1) + 1))
+}
+
+// taggedSize is the tag and data size of a known tagged field being encoded.
+type taggedSize struct {
+	tag  uint64
+	size int
+}
+
+// taggedFieldsSize returns the encoded size of a tagged field section holding
+// the unknown fields and the known ones, which replace unknown fields with the
+// same tag, as Encode does.
+func taggedFieldsSize(unknown decoder.TaggedFields, known []taggedSize) int {
+	count, size := len(known), 0
+	for _, f := range known {
+		size += uvarintSize(f.tag) + uvarintSize(uint64(f.size)) + f.size
+	}
+	for _, f := range unknown {
+		if slices.ContainsFunc(known, func(k taggedSize) bool { return k.tag == f.Tag }) {
+			continue
+		}
+		count++
+		size += uvarintSize(f.Tag) + uvarintSize(uint64(len(f.Data))) + len(f.Data)
+	}
+	return uvarintSize(uint64(count)) + size
+}
+
+// nonNilBytes returns b, or an empty slice when b is nil, so that
+// non-nullable bytes fields are never encoded as null.
+func nonNilBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
+
+// arrayLength returns the encoded length of s, which is -1 for a nil slice
+// when the field is nullable in the version being encoded.
+func arrayLength[T any](s []T, nullable bool) int {
+	if s == nil && nullable {
+		return -1
+	}
+	return len(s)
+}
+
+// stringPtr returns a pointer to s, for the defaults of nullable strings.
+func stringPtr(s string) *string {
+	return &s
+}
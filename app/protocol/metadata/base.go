@@ -3,9 +3,13 @@ package metadata
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"math"
 
+	"github.com/codecrafters-io/kafka-starter-go/app/compression"
 	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
 	"github.com/codecrafters-io/kafka-starter-go/app/encoder"
 )
@@ -44,8 +48,10 @@ type RecordHeader struct {
 	Value []byte
 }
 
+// Encode writes the header key as a varint-length string and the value as
+// varint-length bytes, with -1 for a null value.
 func (r *RecordHeader) Encode(w io.Writer) error {
-	err := encoder.EncodeCompactString(w, r.Key)
+	err := encoder.EncodeSpecialBytes(w, []byte(r.Key))
 	if err != nil {
 		return fmt.Errorf("failed to encode key: %w", err)
 	}
@@ -56,7 +62,14 @@ func (r *RecordHeader) Encode(w io.Writer) error {
 	return nil
 }
 
+func (r *RecordHeader) size() int {
+	return varintBytesSize([]byte(r.Key)) + varintBytesSize(r.Value)
+}
+
+// Encode writes the record, prefixed with its length. Length is computed from
+// the fields and stored on the record.
 func (r *Record) Encode(w io.Writer) error {
+	r.Length = int64(r.bodySize())
 	err := encoder.EncodeVarint(w, r.Length)
 	if err != nil {
 		return fmt.Errorf("failed to encode length: %w", err)
@@ -81,7 +94,7 @@ func (r *Record) Encode(w io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("failed to encode value: %w", err)
 	}
-	err = encoder.EncodeUvarint(w, uint64(len(r.Headers)))
+	err = encoder.EncodeVarint(w, int64(len(r.Headers)))
 	if err != nil {
 		return fmt.Errorf("failed to encode headers length: %w", err)
 	}
@@ -94,8 +107,51 @@ func (r *Record) Encode(w io.Writer) error {
 	return nil
 }
 
+// bodySize returns the encoded size of the record without its leading length varint.
+func (r *Record) bodySize() int {
+	size := 1 + // attributes
+		varintSize(r.TimestampDelta) +
+		varintSize(r.OffsetDelta) +
+		varintBytesSize(r.Key) +
+		varintBytesSize(r.Value) +
+		varintSize(int64(len(r.Headers)))
+	for _, header := range r.Headers {
+		size += header.size()
+	}
+	return size
+}
+
+// varintSize returns the size of v encoded as a zig-zag varint.
+func varintSize(v int64) int {
+	u := uint64(v)<<1 ^ uint64(v>>63)
+	size := 1
+	for u >= 0x80 {
+		u >>= 7
+		size++
+	}
+	return size
+}
+
+// varintBytesSize returns the size of b encoded with a varint length, which is -1 for nil.
+func varintBytesSize(b []byte) int {
+	if b == nil {
+		return varintSize(-1)
+	}
+	return varintSize(int64(len(b))) + len(b)
+}
+
+// Encode writes the batch with its BatchLength and CRC computed from the
+// serialized attributes and records, and stores both on the batch.
 func (r *RecordBatch) Encode(w io.Writer) error {
-	err := encoder.EncodeValue(w, r.BaseOffset)
+	var body bytes.Buffer
+	err := r.encodeBody(&body)
+	if err != nil {
+		return err
+	}
+	r.BatchLength = int32(BatchAttributesPos - BatchLogOverhead + body.Len())
+	r.CRC = int32(crc32.Checksum(body.Bytes(), castagnoliTable))
+
+	err = encoder.EncodeValue(w, r.BaseOffset)
 	if err != nil {
 		return fmt.Errorf("failed to encode base offset: %w", err)
 	}
@@ -115,7 +171,17 @@ func (r *RecordBatch) Encode(w io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("failed to encode crc: %w", err)
 	}
-	err = encoder.EncodeValue(w, r.Attributes)
+	_, err = w.Write(body.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to encode batch body: %w", err)
+	}
+	return nil
+}
+
+// encodeBody writes the part of the batch covered by the CRC: the attributes
+// and everything after them.
+func (r *RecordBatch) encodeBody(w io.Writer) error {
+	err := encoder.EncodeValue(w, r.Attributes)
 	if err != nil {
 		return fmt.Errorf("failed to encode attributes: %w", err)
 	}
@@ -147,54 +213,59 @@ func (r *RecordBatch) Encode(w io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("failed to encode records length: %w", err)
 	}
-	for _, record := range r.Records {
-		err = record.Encode(w)
+	// The records are compressed as a whole; the count stays uncompressed.
+	var records bytes.Buffer
+	for i := range r.Records {
+		err = r.Records[i].Encode(&records)
 		if err != nil {
 			return fmt.Errorf("failed to encode record: %w", err)
 		}
 	}
+	compressed, err := compression.Compress(r.Compression(), records.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to compress records: %w", err)
+	}
+	_, err = w.Write(compressed)
+	if err != nil {
+		return fmt.Errorf("failed to encode records: %w", err)
+	}
 	return nil
 }
 
 func DecodeRecordHeader(r *bufio.Reader) (*RecordHeader, error) {
 	header := &RecordHeader{}
-	var err error
-	header.Key, err = decoder.DecodeCompactString(r)
+	key, err := decoder.DecodeSpecialBytes(r)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to decode header key: %w", err)
+	}
+	if key == nil {
+		return nil, errors.New("null header key")
 	}
+	header.Key = string(key)
 	header.Value, err = decoder.DecodeSpecialBytes(r)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to decode header value: %w", err)
 	}
 	return header, nil
 }
 
 // decodeSpecificRecordValue is a helper to decode the specific record type from the value bytes.
-func decodeSpecificRecordValue(rd *bufio.Reader, recordType RecordType) (valueEncodedRecord any, valueEncodedRecordType RecordType, err error) {
-	switch recordType {
-	case RecordTypePartition:
-		valueEncodedRecord, err = DecodePartitionRecord(rd)
-		valueEncodedRecordType = RecordTypePartition
-		if err != nil {
-			return nil, 0, err // Return zero value for RecordType on error
-		}
-	case RecordTypeTopic:
-		valueEncodedRecord, err = DecodeTopicRecord(rd)
-		valueEncodedRecordType = RecordTypeTopic
-		if err != nil {
-			return nil, 0, err
-		}
-	case RecordTypeFeatureLevel:
-		valueEncodedRecord, err = DecodeFeatureLevelRecord(rd)
-		valueEncodedRecordType = RecordTypeFeatureLevel
-		if err != nil {
-			return nil, 0, err
-		}
-	default:
-		return nil, 0, fmt.Errorf("invalid record type: %d", recordType)
-	}
-	return valueEncodedRecord, valueEncodedRecordType, nil
+// Record types and versions this package does not know, such as ones added by
+// newer Kafka versions, are skipped: the returned record is nil.
+func decodeSpecificRecordValue(rd *bufio.Reader, baseRecord *BaseRecord) (valueEncodedRecord any, valueEncodedRecordType RecordType, err error) {
+	newMessage, ok := generatedRecords[baseRecord.Type]
+	if !ok {
+		return nil, baseRecord.Type, nil
+	}
+	message := newMessage()
+	version := int16(baseRecord.Version)
+	if version < message.LowestSupportedVersion() || version > message.HighestSupportedVersion() {
+		return nil, baseRecord.Type, nil
+	}
+	if err := message.Decode(rd, version); err != nil {
+		return nil, 0, err
+	}
+	return message, baseRecord.Type, nil
 }
 
 func DecodeRecord(r *bufio.Reader, shouldDecodeValue bool) (*Record, error) {
@@ -226,7 +297,7 @@ func DecodeRecord(r *bufio.Reader, shouldDecodeValue bool) (*Record, error) {
 	}
 	if shouldDecodeValue {
 		// encode record.Value
-		rd := bufio.NewReader(bytes.NewReader(record.Value))
+		rd := decoder.NewReader(record.Value)
 		baseRecord, err := DecodeBaseRecord(rd)
 		if err != nil {
 			return nil, err
@@ -234,51 +305,44 @@ func DecodeRecord(r *bufio.Reader, shouldDecodeValue bool) (*Record, error) {
 		record.ValueEncodedBaseRecode = *baseRecord
 
 		// Call the new helper function
-		record.ValueEncodedRecord, record.ValueEncodedRecordType, err = decodeSpecificRecordValue(rd, baseRecord.Type)
+		record.ValueEncodedRecord, record.ValueEncodedRecordType, err = decodeSpecificRecordValue(rd, baseRecord)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode specific record value: %w", err)
 		}
-	} else {
-		fmt.Println("STRING", string(record.Value))
 	}
 
-	// finished decode record.Value
-	headerLength, err := decoder.DecodeUvarint(r)
+	headerCount, err := decoder.DecodeVarint(r)
 	if err != nil {
 		return nil, err
 	}
-	if headerLength > 0 {
-		// The Kafka protocol specifies header count as Uvarint, not Uvarint-1.
-		// If headerLength from DecodeUvarint is 0, it means no headers.
-		// If it's > 0, it's the actual count. Let's assume it's count, not count+1 for now.
-		// If it was count+1, then 0 would mean null, 1 would mean 0 headers.
-		// For safety, let's assume headerLength is the actual count and if it's >0, process.
-		actualHeaderCount := int(headerLength)
-		if headerLength == 0 { // Check if this is how zero headers is represented
-			// No headers, do nothing or ensure record.Headers is nil/empty
-			record.Headers = nil
-		} else {
-			// If Uvarint 0 means null and Uvarint 1 means 0 elements for compact arrays, then:
-			// compact_array_len, err := decoder.DecodeCompactArrayLength(rd) // If using a helper for this
-			// if err == nil && compact_array_len > 0 {
-			//    record.Headers = make([]RecordHeader, compact_array_len)
-			// } else if err != nil { return nil, err }
-			// For now, assuming simple Uvarint count
-
-			record.Headers = make([]RecordHeader, actualHeaderCount)
-			for i := range record.Headers {
-				header, err := DecodeRecordHeader(r) // IMPORTANT: Use original reader 'r', not 'rd' from record.Value
-				if err != nil {
-					return nil, err
-				}
-				record.Headers[i] = *header
-			}
+	if headerCount < 0 {
+		return nil, fmt.Errorf("invalid header count %d", headerCount)
+	}
+	// The count is not trusted for preallocation; the headers must all be read.
+	for range headerCount {
+		header, err := DecodeRecordHeader(r)
+		if err != nil {
+			return nil, err
 		}
+		record.Headers = append(record.Headers, *header)
+	}
+	if size := record.bodySize(); int64(size) != record.Length {
+		return nil, fmt.Errorf("record length %d does not match its %d encoded bytes", record.Length, size)
 	}
 	return record, nil
 }
 
-func DecodeRecordBatch(r *bufio.Reader, shouldDecodeValue bool) (*RecordBatch, error) {
+// MaxStoredRecordsSize bounds the decompressed records of batches read back
+// from a log. Produced batches were checked against message.max.bytes before
+// they were stored, so reads only need the largest size a batch can have.
+const MaxStoredRecordsSize = math.MaxInt32
+
+// DecodeRecordBatch decodes the next record batch from r. The CRC is checked
+// before the records are decoded; a mismatch is reported as ErrCorruptBatch,
+// even when the corruption also made the records undecodable. Compressed
+// records decompressing to more than maxRecordsSize bytes fail with
+// compression.ErrTooLarge.
+func DecodeRecordBatch(r *bufio.Reader, shouldDecodeValue bool, maxRecordsSize int) (*RecordBatch, error) {
 	recordBatch := &RecordBatch{}
 	var err error
 	err = decoder.DecodeValue(r, &recordBatch.BaseOffset)
@@ -289,6 +353,9 @@ func DecodeRecordBatch(r *bufio.Reader, shouldDecodeValue bool) (*RecordBatch, e
 	if err != nil {
 		return nil, err
 	}
+	if recordBatch.BatchLength < BatchHeaderSize-BatchLogOverhead {
+		return nil, fmt.Errorf("invalid batch length %d", recordBatch.BatchLength)
+	}
 	err = decoder.DecodeValue(r, &recordBatch.PartitionLeaderEpoch)
 	if err != nil {
 		return nil, err
@@ -301,48 +368,110 @@ func DecodeRecordBatch(r *bufio.Reader, shouldDecodeValue bool) (*RecordBatch, e
 	if err != nil {
 		return nil, err
 	}
-	err = decoder.DecodeValue(r, &recordBatch.Attributes)
+
+	// Everything after the CRC is covered by the checksum.
+	data, err := decoder.DecodeRawBytes(r, uint64(recordBatch.BatchLength)-(BatchAttributesPos-BatchLogOverhead))
+	if err != nil {
+		return nil, fmt.Errorf("truncated record batch: %w", io.ErrUnexpectedEOF)
+	}
+	if checksum := crc32.Checksum(data, castagnoliTable); checksum != uint32(recordBatch.CRC) {
+		return nil, fmt.Errorf("%w: stored crc %#08x, computed %#08x", ErrCorruptBatch, uint32(recordBatch.CRC), checksum)
+	}
+	body := decoder.NewReader(data)
+	err = decodeRecordBatchBody(body, recordBatch, shouldDecodeValue, maxRecordsSize)
 	if err != nil {
+		if errors.Is(err, io.EOF) {
+			// The records overran the batch length; this is not the end of the input.
+			return nil, fmt.Errorf("records exceed batch length: %w", io.ErrUnexpectedEOF)
+		}
 		return nil, err
 	}
+	if n := body.Buffered(); n != 0 {
+		return nil, fmt.Errorf("record batch has %d trailing bytes", n)
+	}
+	return recordBatch, nil
+}
+
+// decodeRecordBatchBody decodes the part of the batch covered by the CRC.
+func decodeRecordBatchBody(r *bufio.Reader, recordBatch *RecordBatch, shouldDecodeValue bool, maxRecordsSize int) error {
+	err := decoder.DecodeValue(r, &recordBatch.Attributes)
+	if err != nil {
+		return err
+	}
 	err = decoder.DecodeValue(r, &recordBatch.LastOffsetDelta)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	err = decoder.DecodeValue(r, &recordBatch.FirstTimestamp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	err = decoder.DecodeValue(r, &recordBatch.MaxTimestamp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	err = decoder.DecodeValue(r, &recordBatch.ProducerId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	err = decoder.DecodeValue(r, &recordBatch.ProducerEpoch)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	err = decoder.DecodeValue(r, &recordBatch.BaseSequence)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	var lengthRecords int32
 	err = decoder.DecodeValue(r, &lengthRecords)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	recordBatch.Records = make([]Record, lengthRecords)
-	for i := range recordBatch.Records {
+	if lengthRecords < 0 {
+		return fmt.Errorf("invalid records count %d", lengthRecords)
+	}
+	if codec := recordBatch.Compression(); codec != compression.None {
+		// r ends with the batch, so the rest of it is the compressed records.
+		compressed, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		records, err := compression.Decompress(codec, compressed, maxRecordsSize)
+		if err != nil {
+			return fmt.Errorf("failed to decompress records: %w", err)
+		}
+		rd := decoder.NewReader(records)
+		err = decodeRecords(rd, recordBatch, lengthRecords, shouldDecodeValue)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// The decompressed records ended early; the batch itself was complete.
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("failed to decode %s compressed records: %w", codec, err)
+		}
+		if trailing := rd.Buffered(); trailing != 0 {
+			return fmt.Errorf("compressed records have %d trailing bytes", trailing)
+		}
+		return nil
+	}
+	return decodeRecords(r, recordBatch, lengthRecords, shouldDecodeValue)
+}
+
+// decodeRecords decodes count records into recordBatch.
+func decodeRecords(r *bufio.Reader, recordBatch *RecordBatch, count int32, shouldDecodeValue bool) error {
+	// The count is not trusted for preallocation; the records must all be read.
+	recordBatch.Records = nil
+	// Control batches hold transaction markers and KRaft control records such as
+	// LeaderChangeMessage, whose values are not metadata records.
+	shouldDecodeValue = shouldDecodeValue && !recordBatch.IsControl()
+	for range count {
 		recordInternal, err := DecodeRecord(r, shouldDecodeValue)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		recordBatch.Records[i] = *recordInternal
+		recordBatch.Records = append(recordBatch.Records, *recordInternal)
 	}
-	return recordBatch, nil
+	return nil
 }
 
 type BaseRecord struct {
@@ -368,20 +497,13 @@ func DecodeBaseRecord(r *bufio.Reader) (*BaseRecord, error) {
 	return record, nil
 }
 
+// DecodeRecordType decodes the type of a metadata record. Every type is
+// accepted; the types this package does not know are skipped when decoding.
 func DecodeRecordType(r *bufio.Reader) (RecordType, error) {
 	var recordType int8
 	err := decoder.DecodeValue(r, &recordType)
 	if err != nil {
 		return 0, err
 	}
-	switch recordType {
-	case int8(RecordTypeTopic):
-		return RecordTypeTopic, nil
-	case int8(RecordTypePartition):
-		return RecordTypePartition, nil
-	case int8(RecordTypeFeatureLevel):
-		return RecordTypeFeatureLevel, nil
-	default:
-		return 0, fmt.Errorf("invalid record type: %d", recordType)
-	}
+	return RecordType(recordType), nil
 }
@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/compression"
+)
+
+// Byte offsets of the RecordBatch (magic v2) header fields, shared by the
+// code working on raw batches.
+const (
+	BatchBaseOffsetPos      = 0
+	BatchLengthPos          = 8
+	BatchMagicPos           = 16
+	BatchCRCPos             = 17
+	BatchAttributesPos      = 21
+	BatchLastOffsetDeltaPos = 23
+	BatchMaxTimestampPos    = 35
+	BatchRecordsCountPos    = 57
+	BatchHeaderSize         = 61
+
+	// BatchLogOverhead is the size of the BaseOffset and BatchLength fields, which are not counted in BatchLength.
+	BatchLogOverhead = 12
+)
+
+const (
+	// compressionCodecMask selects the compression codec from the batch attributes.
+	compressionCodecMask = 0x07
+	// controlBatchFlag marks batches holding transaction markers.
+	controlBatchFlag = 0x20
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorruptBatch is returned for record batches whose CRC does not match their content.
+var ErrCorruptBatch = errors.New("corrupt record batch")
+
+// BatchCRC computes the CRC-32C of a raw record batch, which covers everything
+// from the attributes to the end of the batch.
+func BatchCRC(batch []byte) uint32 {
+	return crc32.Checksum(batch[BatchAttributesPos:], castagnoliTable)
+}
+
+// VerifyBatchCRC checks the stored CRC of a raw record batch against its content.
+func VerifyBatchCRC(batch []byte) error {
+	if len(batch) < BatchHeaderSize {
+		return fmt.Errorf("truncated record batch of %d bytes", len(batch))
+	}
+	stored := binary.BigEndian.Uint32(batch[BatchCRCPos:])
+	if computed := BatchCRC(batch); stored != computed {
+		return fmt.Errorf("%w: stored crc %#08x, computed %#08x", ErrCorruptBatch, stored, computed)
+	}
+	return nil
+}
+
+// Compression returns the codec the records of the batch are compressed with.
+func (r *RecordBatch) Compression() compression.Codec {
+	return compression.Codec(r.Attributes & compressionCodecMask)
+}
+
+// SetCompression sets the codec the records are compressed with when the batch is encoded.
+func (r *RecordBatch) SetCompression(codec compression.Codec) {
+	r.Attributes = r.Attributes&^compressionCodecMask | int16(codec)
+}
+
+// IsControl reports whether the batch holds control records such as transaction markers.
+func (r *RecordBatch) IsControl() bool {
+	return r.Attributes&controlBatchFlag != 0
+}
+
+// MetadataRecord is a KRaft metadata record that can be written to the cluster metadata log.
+type MetadataRecord interface {
+	RecordType() RecordType
+	Version() int8
+	Encode(w io.Writer) error
+}
+
+// EncodeMetadataRecordValue encodes a metadata record as the value of a log
+// record: frame version, record type and record version followed by its fields.
+func EncodeMetadataRecordValue(record MetadataRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, b := range []int8{1, int8(record.RecordType()), record.Version()} {
+		if err := binary.Write(&buf, binary.BigEndian, b); err != nil {
+			return nil, err
+		}
+	}
+	if err := record.Encode(&buf); err != nil {
+		return nil, fmt.Errorf("failed to encode metadata record type %d: %w", record.RecordType(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// NewRecordBatch creates an uncompressed, non-transactional batch holding the
+// records in order. Offset deltas are assigned from 0 and all records share timestamp.
+func NewRecordBatch(timestamp int64, records []Record) *RecordBatch {
+	for i := range records {
+		records[i].OffsetDelta = int64(i)
+	}
+	return &RecordBatch{
+		PartitionLeaderEpoch: 0,
+		Magic:                2,
+		LastOffsetDelta:      int32(len(records) - 1),
+		FirstTimestamp:       timestamp,
+		MaxTimestamp:         timestamp,
+		ProducerId:           -1,
+		ProducerEpoch:        -1,
+		BaseSequence:         -1,
+		Records:              records,
+	}
+}
+
+// EncodeRecordBatch encodes a batch. The record lengths, the batch length and
+// the CRC are computed by RecordBatch.Encode.
+func EncodeRecordBatch(batch *RecordBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := batch.Encode(&buf); err != nil {
+		return nil, fmt.Errorf("failed to encode record batch: %w", err)
+	}
+	return buf.Bytes(), nil
+}
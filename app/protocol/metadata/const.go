@@ -2,8 +2,31 @@ package metadata
 
 type RecordType int8
 
+// Record types of the KRaft metadata log, the apiKey of each metadata record spec.
 const (
-	RecordTypeTopic        RecordType = 2
-	RecordTypePartition    RecordType = 3
-	RecordTypeFeatureLevel RecordType = 12
+	RecordTypeRegisterBroker            RecordType = 0
+	RecordTypeUnregisterBroker          RecordType = 1
+	RecordTypeTopic                     RecordType = 2
+	RecordTypePartition                 RecordType = 3
+	RecordTypeConfig                    RecordType = 4
+	RecordTypePartitionChange           RecordType = 5
+	RecordTypeFenceBroker               RecordType = 7
+	RecordTypeUnfenceBroker             RecordType = 8
+	RecordTypeRemoveTopic               RecordType = 9
+	RecordTypeDelegationToken           RecordType = 10
+	RecordTypeUserScramCredential       RecordType = 11
+	RecordTypeFeatureLevel              RecordType = 12
+	RecordTypeClientQuota               RecordType = 14
+	RecordTypeProducerIds               RecordType = 15
+	RecordTypeBrokerRegistrationChange  RecordType = 17
+	RecordTypeAccessControlEntry        RecordType = 18
+	RecordTypeRemoveAccessControlEntry  RecordType = 19
+	RecordTypeNoOp                      RecordType = 20
+	RecordTypeZkMigrationState          RecordType = 21
+	RecordTypeRemoveUserScramCredential RecordType = 22
+	RecordTypeBeginTransaction          RecordType = 23
+	RecordTypeEndTransaction            RecordType = 24
+	RecordTypeAbortTransaction          RecordType = 25
+	RecordTypeRemoveDelegationToken     RecordType = 26
+	RecordTypeRegisterController        RecordType = 27
 )
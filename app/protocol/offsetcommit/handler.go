@@ -0,0 +1,126 @@
+package offsetcommit
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 2
+	MaxVersion int16 = 9
+)
+
+// OffsetCommitHandler implements the protocol.RequestHandler interface for OffsetCommit requests.
+type OffsetCommitHandler struct {
+	coordinator *coordinator.GroupCoordinator
+	images      *metadataimage.Manager
+}
+
+// NewOffsetCommitHandler creates a new handler for OffsetCommit requests.
+func NewOffsetCommitHandler(groupCoordinator *coordinator.GroupCoordinator, images *metadataimage.Manager) *OffsetCommitHandler {
+	return &OffsetCommitHandler{coordinator: groupCoordinator, images: images}
+}
+
+// ApiKey returns the API key for OffsetCommit requests.
+func (h *OffsetCommitHandler) ApiKey() int16 {
+	return protocol.ApiKeyOffsetCommit
+}
+
+// MinVersion returns the lowest supported version of OffsetCommit requests.
+func (h *OffsetCommitHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of OffsetCommit requests.
+func (h *OffsetCommitHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty OffsetCommit response.
+func (h *OffsetCommitHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.OffsetCommitResponse{Topics: []messages.OffsetCommitResponseTopic{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the OffsetCommit request. Partitions of unknown topics or with
+// oversized metadata are rejected individually; the rest are committed together.
+func (h *OffsetCommitHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling OffsetCommit request", "correlationID", header.CorrelationID)
+	request := &messages.OffsetCommitRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode offset commit request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+	log.Info("Received OffsetCommit request", "groupID", request.GroupId, "memberID", request.MemberId, "generationID", request.GenerationId)
+
+	image := h.images.Image()
+
+	response := &messages.OffsetCommitResponse{
+		ThrottleTimeMs: 0,
+		Topics:         make([]messages.OffsetCommitResponseTopic, len(request.Topics)),
+	}
+	offsets := make(map[coordinator.TopicPartition]coordinator.OffsetAndMetadata)
+	// pending points at the responses of the partitions passed to the coordinator.
+	var pending []*messages.OffsetCommitResponsePartition
+	for i, t := range request.Topics {
+		response.Topics[i] = messages.OffsetCommitResponseTopic{
+			Name:       t.Name,
+			Partitions: make([]messages.OffsetCommitResponsePartition, len(t.Partitions)),
+		}
+		for j, p := range t.Partitions {
+			partitionResponse := &response.Topics[i].Partitions[j]
+			partitionResponse.PartitionIndex = p.PartitionIndex
+			committedMetadata := ""
+			if p.CommittedMetadata != nil {
+				committedMetadata = *p.CommittedMetadata
+			}
+			switch {
+			case !image.HasPartition(t.Name, p.PartitionIndex):
+				partitionResponse.ErrorCode = protocol.ErrorCodeUnknownTopicOrPartition
+			case len(committedMetadata) > coordinator.MaxOffsetMetadataSize:
+				partitionResponse.ErrorCode = protocol.ErrorCodeOffsetMetadataTooLarge
+			default:
+				offsets[coordinator.TopicPartition{Topic: t.Name, Partition: p.PartitionIndex}] = coordinator.OffsetAndMetadata{
+					Offset:      p.CommittedOffset,
+					LeaderEpoch: p.CommittedLeaderEpoch,
+					Metadata:    committedMetadata,
+				}
+				pending = append(pending, partitionResponse)
+			}
+		}
+	}
+
+	errorCode := h.coordinator.CommitOffsets(coordinator.CommitParams{
+		GroupID:         request.GroupId,
+		GenerationID:    request.GenerationId,
+		MemberID:        request.MemberId,
+		GroupInstanceID: request.GroupInstanceId,
+		Offsets:         offsets,
+	})
+	for _, p := range pending {
+		p.ErrorCode = errorCode
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode offset commit response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode offset commit response", "error", err)
+		return
+	}
+	log.Info("Sent OffsetCommit response", "errorCode", errorCode)
+}
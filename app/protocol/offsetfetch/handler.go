@@ -0,0 +1,174 @@
+package offsetfetch
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"sort"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 1
+	MaxVersion int16 = 9
+
+	// FirstBatchedVersion is the first OffsetFetch version fetching offsets for several groups at once.
+	FirstBatchedVersion int16 = 8
+)
+
+// OffsetFetchHandler implements the protocol.RequestHandler interface for OffsetFetch requests.
+type OffsetFetchHandler struct {
+	coordinator *coordinator.GroupCoordinator
+}
+
+// NewOffsetFetchHandler creates a new handler for OffsetFetch requests.
+func NewOffsetFetchHandler(groupCoordinator *coordinator.GroupCoordinator) *OffsetFetchHandler {
+	return &OffsetFetchHandler{coordinator: groupCoordinator}
+}
+
+// ApiKey returns the API key for OffsetFetch requests.
+func (h *OffsetFetchHandler) ApiKey() int16 {
+	return protocol.ApiKeyOffsetFetch
+}
+
+// MinVersion returns the lowest supported version of OffsetFetch requests.
+func (h *OffsetFetchHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of OffsetFetch requests.
+func (h *OffsetFetchHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a OffsetFetch response carrying only errorCode.
+func (h *OffsetFetchHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.OffsetFetchResponse{
+		Topics:    []messages.OffsetFetchResponseTopic{},
+		ErrorCode: errorCode,
+		Groups:    []messages.OffsetFetchResponseGroup{{Topics: []messages.OffsetFetchResponseTopics{}, ErrorCode: errorCode}},
+	}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the OffsetFetch request. Partitions without a committed offset
+// are returned with offset -1.
+func (h *OffsetFetchHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling OffsetFetch request", "correlationID", header.CorrelationID)
+	request := &messages.OffsetFetchRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode offset fetch request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	response := &messages.OffsetFetchResponse{ThrottleTimeMs: 0}
+	if header.ApiVersion < FirstBatchedVersion {
+		setSingleGroupResponse(response, h.fetchGroupOffsets(singleGroupRequest(request)))
+	} else {
+		response.Groups = make([]messages.OffsetFetchResponseGroup, len(request.Groups))
+		for i, g := range request.Groups {
+			response.Groups[i] = h.fetchGroupOffsets(g)
+		}
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode offset fetch response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode offset fetch response", "error", err)
+		return
+	}
+	log.Info("Sent OffsetFetch response", "groups", max(len(response.Groups), 1))
+}
+
+func (h *OffsetFetchHandler) fetchGroupOffsets(g messages.OffsetFetchRequestGroup) messages.OffsetFetchResponseGroup {
+	response := messages.OffsetFetchResponseGroup{GroupId: g.GroupId, Topics: []messages.OffsetFetchResponseTopics{}}
+	if g.GroupId == "" {
+		response.ErrorCode = protocol.ErrorCodeInvalidGroupID
+		return response
+	}
+	committed := h.coordinator.FetchOffsets(g.GroupId)
+
+	topics := g.Topics
+	if topics == nil {
+		topics = committedTopics(committed)
+	}
+	for _, t := range topics {
+		topicResponse := messages.OffsetFetchResponseTopics{
+			Name:       t.Name,
+			Partitions: make([]messages.OffsetFetchResponsePartitions, len(t.PartitionIndexes)),
+		}
+		for j, partition := range t.PartitionIndexes {
+			p := &topicResponse.Partitions[j]
+			p.PartitionIndex = partition
+			offset, ok := committed[coordinator.TopicPartition{Topic: t.Name, Partition: partition}]
+			if !ok {
+				noMetadata := ""
+				p.CommittedOffset = -1
+				p.CommittedLeaderEpoch = -1
+				p.Metadata = &noMetadata
+				continue
+			}
+			p.CommittedOffset = offset.Offset
+			p.CommittedLeaderEpoch = offset.LeaderEpoch
+			p.Metadata = &offset.Metadata
+		}
+		response.Topics = append(response.Topics, topicResponse)
+	}
+	return response
+}
+
+// singleGroupRequest returns the group named at the top level of versions 1-7
+// requests in the layout of the batched versions.
+func singleGroupRequest(request *messages.OffsetFetchRequest) messages.OffsetFetchRequestGroup {
+	group := messages.OffsetFetchRequestGroup{GroupId: request.GroupId}
+	if request.Topics != nil {
+		group.Topics = make([]messages.OffsetFetchRequestTopics, len(request.Topics))
+		for i, t := range request.Topics {
+			group.Topics[i] = messages.OffsetFetchRequestTopics(t)
+		}
+	}
+	return group
+}
+
+// setSingleGroupResponse moves the offsets of a group to the top level of a
+// versions 1-7 response.
+func setSingleGroupResponse(response *messages.OffsetFetchResponse, group messages.OffsetFetchResponseGroup) {
+	response.ErrorCode = group.ErrorCode
+	response.Topics = make([]messages.OffsetFetchResponseTopic, len(group.Topics))
+	for i, t := range group.Topics {
+		response.Topics[i] = messages.OffsetFetchResponseTopic{
+			Name:       t.Name,
+			Partitions: make([]messages.OffsetFetchResponsePartition, len(t.Partitions)),
+		}
+		for j, p := range t.Partitions {
+			response.Topics[i].Partitions[j] = messages.OffsetFetchResponsePartition(p)
+		}
+	}
+}
+
+// committedTopics lists every partition with a committed offset, sorted by topic and partition.
+func committedTopics(committed map[coordinator.TopicPartition]coordinator.OffsetAndMetadata) []messages.OffsetFetchRequestTopics {
+	byTopic := make(map[string][]int32)
+	for tp := range committed {
+		byTopic[tp.Topic] = append(byTopic[tp.Topic], tp.Partition)
+	}
+	topics := make([]messages.OffsetFetchRequestTopics, 0, len(byTopic))
+	for name, partitions := range byTopic {
+		sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+		topics = append(topics, messages.OffsetFetchRequestTopics{Name: name, PartitionIndexes: partitions})
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Name < topics[j].Name })
+	return topics
+}
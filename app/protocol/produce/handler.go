@@ -0,0 +1,184 @@
+package produce
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/compression"
+	"github.com/codecrafters-io/kafka-starter-go/app/inspect"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/codecrafters-io/kafka-starter-go/app/purgatory"
+	"github.com/codecrafters-io/kafka-starter-go/app/storage"
+)
+
+const (
+	MinVersion int16 = 3
+	MaxVersion int16 = 11
+)
+
+// ProduceHandler implements the protocol.RequestHandler interface for Produce requests.
+// Appends wake the fetches parked in the fetch purgatory on the same partition.
+// Appended records are passed to the inspector. Batches larger than
+// maxMessageBytes, or decompressing to more, are rejected with MESSAGE_TOO_LARGE.
+type ProduceHandler struct {
+	images          *metadataimage.Manager
+	logs            *storage.LogManager
+	fetches         *purgatory.Purgatory
+	inspector       inspect.Inspector
+	maxMessageBytes int
+}
+
+// NewProduceHandler creates a new handler for Produce requests.
+func NewProduceHandler(images *metadataimage.Manager, logs *storage.LogManager, fetches *purgatory.Purgatory, inspector inspect.Inspector, maxMessageBytes int) *ProduceHandler {
+	return &ProduceHandler{images: images, logs: logs, fetches: fetches, inspector: inspector, maxMessageBytes: maxMessageBytes}
+}
+
+// ApiKey returns the API key for Produce requests.
+func (h *ProduceHandler) ApiKey() int16 {
+	return protocol.ApiKeyProduce
+}
+
+// MinVersion returns the lowest supported version of Produce requests.
+func (h *ProduceHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of Produce requests.
+func (h *ProduceHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty Produce response.
+func (h *ProduceHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.ProduceResponse{Responses: []messages.ProduceResponseTopicProduceResponse{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the Produce request.
+func (h *ProduceHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling Produce request", "correlationID", header.CorrelationID)
+	request := &messages.ProduceRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode produce request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	image := h.images.Image()
+
+	response := &messages.ProduceResponse{
+		ThrottleTimeMs: 0,
+		Responses:      make([]messages.ProduceResponseTopicProduceResponse, len(request.TopicData)),
+	}
+	for i, t := range request.TopicData {
+		response.Responses[i] = messages.ProduceResponseTopicProduceResponse{
+			Name:               t.Name,
+			PartitionResponses: make([]messages.ProduceResponsePartitionProduceResponse, len(t.PartitionData)),
+		}
+		for j, p := range t.PartitionData {
+			partitionResponse := &response.Responses[i].PartitionResponses[j]
+			switch {
+			case request.Acks != 0 && request.Acks != 1 && request.Acks != -1:
+				*partitionResponse = newPartitionResponse(p.Index, protocol.ErrorCodeInvalidRequiredAcks, nil)
+			case !image.HasPartition(t.Name, p.Index):
+				*partitionResponse = newPartitionResponse(p.Index, protocol.ErrorCodeUnknownTopicOrPartition, nil)
+			default:
+				*partitionResponse = h.appendPartition(log, t.Name, image.TopicConfigs(t.Name)["compression.type"], p)
+			}
+		}
+	}
+
+	if request.Acks == 0 {
+		// The client does not expect a response when acks=0.
+		log.Info("Produce request with acks=0 handled, not sending a response")
+		return
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode produce response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode produce response", "error", err)
+		return
+	}
+	log.Info("Sent Produce response")
+}
+
+// appendPartition validates the record batches of a partition, assigns their
+// offsets and appends them to the partition log. Unless compressionType is
+// empty or "producer", the batches are stored compressed with that codec.
+func (h *ProduceHandler) appendPartition(log *slog.Logger, topicName string, compressionType string, partition messages.ProduceRequestPartitionProduceData) messages.ProduceResponsePartitionProduceResponse {
+	batches, err := splitRecordBatches(partition.Records, h.maxMessageBytes)
+	if errors.Is(err, errBatchTooLarge) || errors.Is(err, compression.ErrTooLarge) {
+		log.Warn("rejecting oversized record batch", "topic", topicName, "partition", partition.Index, "error", err)
+		message := err.Error()
+		return newPartitionResponse(partition.Index, protocol.ErrorCodeMessageTooLarge, &message)
+	}
+	if err != nil {
+		log.Warn("rejecting invalid record batches", "topic", topicName, "partition", partition.Index, "error", err)
+		message := err.Error()
+		return newPartitionResponse(partition.Index, protocol.ErrorCodeCorruptMessage, &message)
+	}
+	records := partition.Records
+	if compressionType != "" {
+		codec, producer, err := compression.ParseType(compressionType)
+		switch {
+		case err != nil:
+			log.Warn("ignoring invalid compression.type", "topic", topicName, "error", err)
+		case !producer:
+			records, err = recompressBatches(batches, codec, h.maxMessageBytes)
+			if err != nil {
+				log.Error("failed to recompress record batches", "topic", topicName, "partition", partition.Index, "codec", codec, "error", err)
+				return newPartitionResponse(partition.Index, protocol.ErrorCodeUnknownServerError, nil)
+			}
+		}
+	}
+
+	partitionLog, err := h.logs.Log(topicName, partition.Index)
+	if errors.Is(err, storage.ErrLogDeleted) {
+		// The topic was deleted after it was looked up.
+		return newPartitionResponse(partition.Index, protocol.ErrorCodeUnknownTopicOrPartition, nil)
+	}
+	if err != nil {
+		log.Error("failed to open partition log", "topic", topicName, "partition", partition.Index, "error", err)
+		return newPartitionResponse(partition.Index, protocol.ErrorCodeKafkaStorageError, nil)
+	}
+	baseOffset, err := partitionLog.Append(records)
+	if err != nil {
+		log.Error("failed to append to partition log", "topic", topicName, "partition", partition.Index, "error", err)
+		return newPartitionResponse(partition.Index, protocol.ErrorCodeKafkaStorageError, nil)
+	}
+	log.Info("Appended record batches", "topic", topicName, "partition", partition.Index, "baseOffset", baseOffset, "batches", len(batches))
+	h.fetches.Wake(purgatory.Key{Topic: topicName, Partition: partition.Index})
+	// Append assigned the offsets in place, so the inspector sees them.
+	h.inspector.Inspect(log, inspect.Produce, topicName, partition.Index, records)
+
+	response := newPartitionResponse(partition.Index, protocol.ErrorCodeNone, nil)
+	response.BaseOffset = baseOffset
+	response.LogStartOffset = partitionLog.LogStartOffset()
+	return response
+}
+
+// newPartitionResponse returns a partition response without offsets. The
+// current leader keeps its default so it is left out of v10+ responses.
+func newPartitionResponse(index int32, errorCode int16, message *string) messages.ProduceResponsePartitionProduceResponse {
+	var response messages.ProduceResponsePartitionProduceResponse
+	response.SetDefaults()
+	response.Index = index
+	response.ErrorCode = errorCode
+	response.BaseOffset = -1
+	response.RecordErrors = []messages.ProduceResponseBatchIndexAndErrorMessage{}
+	response.ErrorMessage = message
+	return response
+}
@@ -0,0 +1,91 @@
+package produce
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/compression"
+	"github.com/codecrafters-io/kafka-starter-go/app/decoder"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// errBatchTooLarge is returned for record batches larger than message.max.bytes.
+var errBatchTooLarge = errors.New("record batch too large")
+
+// splitRecordBatches splits the RECORDS payload of a partition into raw record
+// batches and validates every batch. Batches, and the decompressed records of
+// a batch, may not be larger than maxBatchSize.
+func splitRecordBatches(records []byte, maxBatchSize int) ([][]byte, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no record batches")
+	}
+	var batches [][]byte
+	for pos := 0; pos < len(records); {
+		if len(records)-pos < metadata.BatchHeaderSize {
+			return nil, fmt.Errorf("truncated record batch header at byte %d", pos)
+		}
+		batchLength := int32(binary.BigEndian.Uint32(records[pos+metadata.BatchLengthPos:]))
+		size := int(batchLength) + metadata.BatchLogOverhead
+		if size < metadata.BatchHeaderSize || pos+size > len(records) {
+			return nil, fmt.Errorf("invalid batch length %d at byte %d", batchLength, pos)
+		}
+		if size > maxBatchSize {
+			return nil, fmt.Errorf("%w: %d bytes at byte %d, limit %d", errBatchTooLarge, size, pos, maxBatchSize)
+		}
+		batch := records[pos : pos+size]
+		if err := validateRecordBatch(batch, maxBatchSize); err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+		pos += size
+	}
+	return batches, nil
+}
+
+func validateRecordBatch(batch []byte, maxRecordsSize int) error {
+	if magic := int8(batch[metadata.BatchMagicPos]); magic != 2 {
+		return fmt.Errorf("unsupported record batch magic %d", magic)
+	}
+	lastOffsetDelta := int32(binary.BigEndian.Uint32(batch[metadata.BatchLastOffsetDeltaPos:]))
+	recordsCount := int32(binary.BigEndian.Uint32(batch[metadata.BatchRecordsCountPos:]))
+	if recordsCount <= 0 || lastOffsetDelta != recordsCount-1 {
+		return fmt.Errorf("invalid record count %d for last offset delta %d", recordsCount, lastOffsetDelta)
+	}
+	// The CRC also covers compressed payloads, so it is checked for every batch.
+	if err := metadata.VerifyBatchCRC(batch); err != nil {
+		return err
+	}
+	// Compressed records are inflated by the decoder.
+	rd := decoder.NewReader(batch)
+	if _, err := metadata.DecodeRecordBatch(rd, false, maxRecordsSize); err != nil {
+		return fmt.Errorf("failed to decode record batch: %w", err)
+	}
+	if rd.Buffered() != 0 {
+		return fmt.Errorf("record batch has %d trailing bytes", rd.Buffered())
+	}
+	return nil
+}
+
+// recompressBatches returns the batches as a RECORDS payload, with every batch
+// compressed with codec. Batches already using codec and control batches are
+// copied unchanged; the others are decoded and encoded again.
+func recompressBatches(batches [][]byte, codec compression.Codec, maxRecordsSize int) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, raw := range batches {
+		batch, err := metadata.DecodeRecordBatch(decoder.NewReader(raw), false, maxRecordsSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode record batch: %w", err)
+		}
+		if batch.Compression() == codec || batch.IsControl() {
+			buf.Write(raw)
+			continue
+		}
+		batch.SetCompression(codec)
+		if err := batch.Encode(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode %s record batch: %w", codec, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,103 @@
+package syncgroup
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/coordinator"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+)
+
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 5
+)
+
+// SyncGroupHandler implements the protocol.AsyncRequestHandler interface for SyncGroup requests.
+type SyncGroupHandler struct {
+	coordinator *coordinator.GroupCoordinator
+}
+
+// NewSyncGroupHandler creates a new handler for SyncGroup requests.
+func NewSyncGroupHandler(groupCoordinator *coordinator.GroupCoordinator) *SyncGroupHandler {
+	return &SyncGroupHandler{coordinator: groupCoordinator}
+}
+
+// ApiKey returns the API key for SyncGroup requests.
+func (h *SyncGroupHandler) ApiKey() int16 {
+	return protocol.ApiKeySyncGroup
+}
+
+// MinVersion returns the lowest supported version of SyncGroup requests.
+func (h *SyncGroupHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of SyncGroup requests.
+func (h *SyncGroupHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes a SyncGroup response carrying only errorCode.
+func (h *SyncGroupHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.SyncGroupResponse{ErrorCode: errorCode, Assignment: []byte{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the SyncGroup request, blocking until it completes.
+func (h *SyncGroupHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	done := make(chan []byte, 1)
+	h.HandleAsync(log, rd, header, func(response []byte) {
+		done <- response
+	})
+	if _, err := w.Write(<-done); err != nil {
+		log.Error("failed to write sync group response", "error", err)
+	}
+}
+
+// HandleAsync handles the SyncGroup request. Followers wait until the leader
+// has sent the group assignment, so the response is awaited off the
+// connection's dispatch path.
+func (h *SyncGroupHandler) HandleAsync(log *slog.Logger, rd *bufio.Reader, header *protocol.RequestHeader, respond func(response []byte)) {
+	log.Info("Handling SyncGroup request", "correlationID", header.CorrelationID)
+	request := &messages.SyncGroupRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode sync group request", "error", err)
+		respond(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+	log.Info("Received SyncGroup request", "groupID", request.GroupId, "memberID", request.MemberId, "generationID", request.GenerationId)
+
+	assignments := make(map[string][]byte, len(request.Assignments))
+	for _, a := range request.Assignments {
+		assignments[a.MemberId] = a.Assignment
+	}
+	results := h.coordinator.SyncGroup(coordinator.SyncParams{
+		GroupID:         request.GroupId,
+		GenerationID:    request.GenerationId,
+		MemberID:        request.MemberId,
+		GroupInstanceID: request.GroupInstanceId,
+		ProtocolType:    request.ProtocolType,
+		ProtocolName:    request.ProtocolName,
+		Assignments:     assignments,
+	})
+
+	protocol.RespondAsync(log, h, header, respond, func() protocol.Message {
+		result := <-results
+		response := &messages.SyncGroupResponse{
+			ThrottleTimeMs: 0,
+			ErrorCode:      result.ErrorCode,
+			ProtocolType:   result.ProtocolType,
+			ProtocolName:   result.ProtocolName,
+			Assignment:     result.Assignment,
+		}
+		log.Info("Sending SyncGroup response", "errorCode", response.ErrorCode)
+		return response
+	})
+}
@@ -0,0 +1,167 @@
+package topicmetadata
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/config"
+	"github.com/codecrafters-io/kafka-starter-go/app/metadataimage"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/messages"
+	"github.com/google/uuid"
+)
+
+const (
+	MinVersion int16 = 0
+	MaxVersion int16 = 12
+)
+
+// AuthorizedOperationsOmitted is reported when authorized operations were not requested.
+const AuthorizedOperationsOmitted int32 = -2147483648
+
+// MetadataHandler implements the protocol.RequestHandler interface for Metadata requests.
+type MetadataHandler struct {
+	config *config.Config
+	images *metadataimage.Manager
+}
+
+// NewMetadataHandler creates a new handler for Metadata requests.
+// The broker advertised to clients is taken from the server configuration.
+func NewMetadataHandler(cfg *config.Config, images *metadataimage.Manager) *MetadataHandler {
+	return &MetadataHandler{config: cfg, images: images}
+}
+
+// ApiKey returns the API key for Metadata requests.
+func (h *MetadataHandler) ApiKey() int16 {
+	return protocol.ApiKeyMetadata
+}
+
+// MinVersion returns the lowest supported version of Metadata requests.
+func (h *MetadataHandler) MinVersion() int16 {
+	return MinVersion
+}
+
+// MaxVersion returns the highest supported version of Metadata requests.
+func (h *MetadataHandler) MaxVersion() int16 {
+	return MaxVersion
+}
+
+// EncodeErrorResponse writes an empty Metadata response.
+func (h *MetadataHandler) EncodeErrorResponse(w io.Writer, header *protocol.RequestHeader, errorCode int16) error {
+	if err := protocol.EncodeResponseHeader(w, header); err != nil {
+		return err
+	}
+	response := &messages.MetadataResponse{Brokers: []messages.MetadataResponseBroker{}, ControllerId: -1, Topics: []messages.MetadataResponseTopic{}}
+	return response.Encode(w, header.ApiVersion)
+}
+
+// Handle handles the Metadata request.
+func (h *MetadataHandler) Handle(log *slog.Logger, rd *bufio.Reader, w io.Writer, header *protocol.RequestHeader) {
+	log.Info("Handling Metadata request", "correlationID", header.CorrelationID)
+	request := &messages.MetadataRequest{}
+	err := request.Decode(rd, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to decode metadata request", "error", err)
+		w.Write(protocol.ErrorResponse(log, h, header, protocol.ErrorCodeInvalidRequest))
+		return
+	}
+
+	image := h.images.Image()
+
+	var clusterID *string
+	if h.config.ClusterID != "" {
+		clusterID = &h.config.ClusterID
+	}
+	response := &messages.MetadataResponse{
+		ThrottleTimeMs: 0,
+		Brokers: []messages.MetadataResponseBroker{
+			{
+				NodeId: h.config.NodeID,
+				Host:   h.config.AdvertisedHost,
+				Port:   int32(h.config.Port),
+			},
+		},
+		ClusterId:                   clusterID,
+		ControllerId:                h.config.NodeID,
+		ClusterAuthorizedOperations: AuthorizedOperationsOmitted,
+	}
+
+	// Version 0 has no null topics and asks for all topics with an empty list.
+	if request.Topics == nil || header.ApiVersion == 0 && len(request.Topics) == 0 {
+		names := image.TopicNames()
+		response.Topics = make([]messages.MetadataResponseTopic, 0, len(names))
+		for _, name := range names {
+			response.Topics = append(response.Topics, topicResponse(image.TopicByName(name)))
+		}
+	} else {
+		response.Topics = make([]messages.MetadataResponseTopic, len(request.Topics))
+		for i, t := range request.Topics {
+			response.Topics[i] = h.requestedTopicResponse(image, t)
+		}
+	}
+
+	err = protocol.EncodeResponseHeader(w, header)
+	if err != nil {
+		log.Error("failed to encode metadata response header", "error", err)
+		return
+	}
+	err = response.Encode(w, header.ApiVersion)
+	if err != nil {
+		log.Error("failed to encode metadata response", "error", err)
+		return
+	}
+	log.Info("Sent Metadata response", "topics", len(response.Topics))
+}
+
+// requestedTopicResponse resolves a requested topic by name, or by topic id when no name is given.
+func (h *MetadataHandler) requestedTopicResponse(image *metadataimage.MetadataImage, t messages.MetadataRequestTopic) messages.MetadataResponseTopic {
+	if t.Name == nil {
+		topic := image.TopicByID(t.TopicId)
+		if topic == nil {
+			return messages.MetadataResponseTopic{
+				ErrorCode:                 protocol.ErrorCodeUnknownTopicID,
+				TopicId:                   t.TopicId,
+				Partitions:                []messages.MetadataResponsePartition{},
+				TopicAuthorizedOperations: AuthorizedOperationsOmitted,
+			}
+		}
+		return topicResponse(topic)
+	}
+	topic := image.TopicByName(*t.Name)
+	if topic == nil {
+		return messages.MetadataResponseTopic{
+			ErrorCode:                 protocol.ErrorCodeUnknownTopicOrPartition,
+			Name:                      t.Name,
+			TopicId:                   uuid.Nil,
+			Partitions:                []messages.MetadataResponsePartition{},
+			TopicAuthorizedOperations: AuthorizedOperationsOmitted,
+		}
+	}
+	return topicResponse(topic)
+}
+
+func topicResponse(topic *metadataimage.TopicImage) messages.MetadataResponseTopic {
+	partitions := topic.SortedPartitions()
+	name := topic.Name
+	response := messages.MetadataResponseTopic{
+		ErrorCode:                 protocol.ErrorCodeNone,
+		Name:                      &name,
+		TopicId:                   topic.ID,
+		IsInternal:                protocol.IsInternalTopic(topic.Name),
+		Partitions:                make([]messages.MetadataResponsePartition, len(partitions)),
+		TopicAuthorizedOperations: AuthorizedOperationsOmitted,
+	}
+	for i, p := range partitions {
+		response.Partitions[i] = messages.MetadataResponsePartition{
+			ErrorCode:       protocol.ErrorCodeNone,
+			PartitionIndex:  p.PartitionId,
+			LeaderId:        p.Leader,
+			LeaderEpoch:     p.LeaderEpoch,
+			ReplicaNodes:    p.Replicas,
+			IsrNodes:        p.Isr,
+			OfflineReplicas: []int32{},
+		}
+	}
+	return response
+}
@@ -0,0 +1,25 @@
+package protocol
+
+// Internal topics hold the state of Kafka itself rather than client data.
+const (
+	ConsumerOffsetsTopic  = "__consumer_offsets"
+	TransactionStateTopic = "__transaction_state"
+)
+
+// internalTopics are the topics Metadata reports as internal.
+var internalTopics = map[string]bool{
+	ConsumerOffsetsTopic:  true,
+	TransactionStateTopic: true,
+}
+
+// IsInternalTopic reports whether name is one of Kafka's internal topics.
+func IsInternalTopic(name string) bool {
+	return internalTopics[name]
+}
+
+// IsReservedTopic reports whether name is reserved for the broker's own logs:
+// an internal topic or the cluster metadata topic. Clients can neither create
+// nor delete these topics.
+func IsReservedTopic(name string) bool {
+	return IsInternalTopic(name) || name == ClusterMetadataTopic
+}
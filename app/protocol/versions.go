@@ -0,0 +1,101 @@
+package protocol
+
+import "math"
+
+// notFlexible marks APIs that have no flexible version.
+const notFlexible int16 = math.MaxInt16
+
+// firstFlexibleVersions maps API keys to the first version that uses compact
+// encodings and tagged fields (KIP-482). Flexible versions also switch to
+// request header v2 and response header v1. APIs that are not listed were
+// added after KIP-482 and are flexible from v0.
+var firstFlexibleVersions = map[int16]int16{
+	ApiKeyProduce:                 9,
+	ApiKeyFetch:                   12,
+	ApiKeyListOffsets:             6,
+	ApiKeyMetadata:                9,
+	4:                             4, // LeaderAndIsr
+	5:                             2, // StopReplica
+	6:                             6, // UpdateMetadata
+	7:                             3, // ControlledShutdown
+	ApiKeyOffsetCommit:            8,
+	ApiKeyOffsetFetch:             6,
+	ApiKeyFindCoordinator:         3,
+	ApiKeyJoinGroup:               6,
+	ApiKeyHeartbeat:               4,
+	ApiKeyLeaveGroup:              4,
+	ApiKeySyncGroup:               4,
+	15:                            5,           // DescribeGroups
+	16:                            3,           // ListGroups
+	17:                            notFlexible, // SaslHandshake
+	ApiKeyApiVersions:             3,
+	ApiKeyCreateTopics:            5,
+	ApiKeyDeleteTopics:            4,
+	21:                            2,           // DeleteRecords
+	22:                            2,           // InitProducerId
+	23:                            4,           // OffsetForLeaderEpoch
+	24:                            3,           // AddPartitionsToTxn
+	25:                            3,           // AddOffsetsToTxn
+	26:                            3,           // EndTxn
+	27:                            1,           // WriteTxnMarkers
+	28:                            3,           // TxnOffsetCommit
+	29:                            2,           // DescribeAcls
+	30:                            2,           // CreateAcls
+	31:                            2,           // DeleteAcls
+	32:                            4,           // DescribeConfigs
+	33:                            2,           // AlterConfigs
+	34:                            2,           // AlterReplicaLogDirs
+	35:                            2,           // DescribeLogDirs
+	36:                            2,           // SaslAuthenticate
+	37:                            2,           // CreatePartitions
+	38:                            2,           // CreateDelegationToken
+	39:                            2,           // RenewDelegationToken
+	40:                            2,           // ExpireDelegationToken
+	41:                            2,           // DescribeDelegationToken
+	42:                            2,           // DeleteGroups
+	43:                            2,           // ElectLeaders
+	44:                            1,           // IncrementalAlterConfigs
+	45:                            0,           // AlterPartitionReassignments
+	46:                            0,           // ListPartitionReassignments
+	47:                            notFlexible, // OffsetDelete
+	48:                            1,           // DescribeClientQuotas
+	49:                            1,           // AlterClientQuotas
+	53:                            1,           // BeginQuorumEpoch
+	54:                            1,           // EndQuorumEpoch
+	ApiKeyDescribeTopicPartitions: 0,
+}
+
+// IsFlexibleVersion reports whether apiVersion of the API uses compact
+// encodings and tagged fields.
+func IsFlexibleVersion(apiKey int16, apiVersion int16) bool {
+	first, ok := firstFlexibleVersions[apiKey]
+	if !ok {
+		return true
+	}
+	return apiVersion >= first
+}
+
+// RequestHeaderVersion returns the request header version used by apiVersion of the API.
+func RequestHeaderVersion(apiKey int16, apiVersion int16) int16 {
+	// ControlledShutdown v0 predates the client id.
+	if apiKey == 7 && apiVersion == 0 {
+		return 0
+	}
+	if IsFlexibleVersion(apiKey, apiVersion) {
+		return 2
+	}
+	return 1
+}
+
+// ResponseHeaderVersion returns the response header version used by apiVersion of the API.
+func ResponseHeaderVersion(apiKey int16, apiVersion int16) int16 {
+	// ApiVersions responses always use response header v0, so that clients
+	// can parse them before they know which versions the broker supports.
+	if apiKey == ApiKeyApiVersions {
+		return 0
+	}
+	if IsFlexibleVersion(apiKey, apiVersion) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+// Package purgatory parks delayed operations, such as Fetch requests waiting
+// for data, until they can complete or their wait time expires.
+package purgatory
+
+import (
+	"sync"
+	"time"
+)
+
+// Key identifies what a delayed operation is waiting on: a topic partition.
+type Key struct {
+	Topic     string
+	Partition int32
+}
+
+// CompleteFunc tries to complete a delayed operation and reports whether it
+// did. When force is set the wait time expired and the operation must complete
+// with whatever is available.
+type CompleteFunc func(force bool) bool
+
+// PanicFunc answers a delayed operation whose CompleteFunc panicked with the
+// recovered value. It runs in place of the completion, which may have been
+// triggered by a timer or by another request.
+type PanicFunc func(r any)
+
+// operation is a parked operation. It completes exactly once.
+type operation struct {
+	keys     []Key
+	complete CompleteFunc
+	panicked PanicFunc
+
+	mu    sync.Mutex
+	done  bool
+	timer *time.Timer
+}
+
+// tryComplete runs the completion function unless the operation already
+// completed. An operation whose completion function panics is completed with
+// its PanicFunc instead.
+func (op *operation) tryComplete(force bool) (completed bool) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	if op.done {
+		return true
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			op.finish()
+			op.panicked(r)
+			completed = true
+		}
+	}()
+	if !op.complete(force) {
+		return false
+	}
+	op.finish()
+	return true
+}
+
+// finish marks the operation as completed and stops its timer. op.mu must be held.
+func (op *operation) finish() {
+	op.done = true
+	if op.timer != nil {
+		op.timer.Stop()
+	}
+}
+
+// Purgatory holds delayed operations, indexed by the keys they watch.
+type Purgatory struct {
+	mu       sync.Mutex
+	watchers map[Key][]*operation
+}
+
+// New creates an empty purgatory.
+func New() *Purgatory {
+	return &Purgatory{watchers: make(map[Key][]*operation)}
+}
+
+// TryCompleteElseWatch completes the operation right away if possible.
+// Otherwise it parks it until Wake is called for one of keys and it can
+// complete, or until timeout expires and it is completed forcibly. If complete
+// panics, panicked is called instead and the operation is dropped.
+func (p *Purgatory) TryCompleteElseWatch(timeout time.Duration, keys []Key, complete CompleteFunc, panicked PanicFunc) {
+	op := &operation{keys: keys, complete: complete, panicked: panicked}
+	if op.tryComplete(false) {
+		return
+	}
+
+	p.mu.Lock()
+	for _, key := range keys {
+		p.watchers[key] = append(p.watchers[key], op)
+	}
+	p.mu.Unlock()
+
+	op.mu.Lock()
+	op.timer = time.AfterFunc(timeout, func() {
+		// The timer runs outside of any request handler, so tryComplete must
+		// recover a panic of the completion; the operation is dropped either way.
+		defer p.remove(op)
+		op.tryComplete(true)
+	})
+	op.mu.Unlock()
+
+	// Data may have arrived between the first attempt and the watch.
+	if op.tryComplete(false) {
+		p.remove(op)
+	}
+}
+
+// Wake tries to complete the operations watching key, e.g. after records
+// were appended to a partition.
+func (p *Purgatory) Wake(key Key) {
+	p.mu.Lock()
+	ops := append([]*operation(nil), p.watchers[key]...)
+	p.mu.Unlock()
+	for _, op := range ops {
+		if op.tryComplete(false) {
+			p.remove(op)
+		}
+	}
+}
+
+// remove drops a completed operation from all the keys it watches.
+func (p *Purgatory) remove(op *operation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, key := range op.keys {
+		ops := p.watchers[key]
+		for i, other := range ops {
+			if other == op {
+				ops = append(ops[:i], ops[i+1:]...)
+				break
+			}
+		}
+		if len(ops) == 0 {
+			delete(p.watchers, key)
+		} else {
+			p.watchers[key] = ops
+		}
+	}
+}
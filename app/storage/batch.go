@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// batchHeader holds the header fields of a record batch the log needs to
+// place and index it.
+type batchHeader struct {
+	baseOffset   int64
+	size         int
+	lastOffset   int64
+	maxTimestamp int64
+}
+
+// parseBatchHeader reads the header of the record batch at the start of data.
+// data must hold at least metadata.BatchHeaderSize bytes.
+func parseBatchHeader(data []byte) (batchHeader, error) {
+	baseOffset := int64(binary.BigEndian.Uint64(data[metadata.BatchBaseOffsetPos:]))
+	batchLength := int32(binary.BigEndian.Uint32(data[metadata.BatchLengthPos:]))
+	size := int(batchLength) + metadata.BatchLogOverhead
+	if size < metadata.BatchHeaderSize {
+		return batchHeader{}, fmt.Errorf("invalid batch length %d", batchLength)
+	}
+	lastOffsetDelta := int32(binary.BigEndian.Uint32(data[metadata.BatchLastOffsetDeltaPos:]))
+	if lastOffsetDelta < 0 {
+		return batchHeader{}, fmt.Errorf("invalid last offset delta %d", lastOffsetDelta)
+	}
+	return batchHeader{
+		baseOffset:   baseOffset,
+		size:         size,
+		lastOffset:   baseOffset + int64(lastOffsetDelta),
+		maxTimestamp: int64(binary.BigEndian.Uint64(data[metadata.BatchMaxTimestampPos:])),
+	}, nil
+}
+
+// splitBatches splits concatenated record batches into single batches.
+func splitBatches(data []byte) ([][]byte, error) {
+	var batches [][]byte
+	for pos := 0; pos < len(data); {
+		if len(data)-pos < metadata.BatchHeaderSize {
+			return nil, fmt.Errorf("truncated record batch header at byte %d", pos)
+		}
+		header, err := parseBatchHeader(data[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("record batch at byte %d: %w", pos, err)
+		}
+		if pos+header.size > len(data) {
+			return nil, fmt.Errorf("truncated record batch at byte %d", pos)
+		}
+		batches = append(batches, data[pos:pos+header.size])
+		pos += header.size
+	}
+	return batches, nil
+}
+
+// verifyBatches checks the CRC of every batch in data, which must hold complete batches.
+func verifyBatches(data []byte) error {
+	batches, err := splitBatches(data)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		if err := metadata.VerifyBatchCRC(batch); err != nil {
+			return fmt.Errorf("batch at offset %d: %w", int64(binary.BigEndian.Uint64(batch[metadata.BatchBaseOffsetPos:])), err)
+		}
+	}
+	return nil
+}
+
+// setBatchBaseOffset overwrites the BaseOffset of a raw record batch. The base
+// offset is not covered by the batch CRC, so the batch stays valid.
+func setBatchBaseOffset(batch []byte, baseOffset int64) {
+	binary.BigEndian.PutUint64(batch[metadata.BatchBaseOffsetPos:], uint64(baseOffset))
+}
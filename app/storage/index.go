@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Index files use Kafka's on-disk format: big-endian fixed size entries, with
+// offsets stored relative to the base offset of the segment.
+const (
+	// offsetIndexEntrySize is the size of an .index entry: relative offset (int32), position (int32).
+	offsetIndexEntrySize = 8
+	// timeIndexEntrySize is the size of a .timeindex entry: timestamp (int64), relative offset (int32).
+	timeIndexEntrySize = 12
+)
+
+type offsetIndexEntry struct {
+	offset   int64
+	position int64
+}
+
+type timeIndexEntry struct {
+	timestamp int64
+	offset    int64
+}
+
+// offsetIndex maps offsets to the file position of the batch holding them.
+// Entries are kept in memory and appended to the index file.
+type offsetIndex struct {
+	file       *os.File
+	baseOffset int64
+	entries    []offsetIndexEntry
+}
+
+// timeIndex maps the largest timestamp seen so far in a segment to the offset
+// of the batch that carried it.
+type timeIndex struct {
+	file       *os.File
+	baseOffset int64
+	entries    []timeIndexEntry
+}
+
+// openIndexFile opens an index file and returns its complete entries. A trailing
+// partial entry, e.g. from a crash during an append, is truncated away.
+func openIndexFile(path string, entrySize int) (*os.File, []byte, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	size := info.Size() - info.Size()%int64(entrySize)
+	data := make([]byte, size)
+	if _, err := file.ReadAt(data, 0); err != nil && size > 0 {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to read index %s: %w", path, err)
+	}
+	if size != info.Size() {
+		if err := file.Truncate(size); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+	}
+	if _, err := file.Seek(size, 0); err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, data, nil
+}
+
+func openOffsetIndex(path string, baseOffset int64) (*offsetIndex, error) {
+	file, data, err := openIndexFile(path, offsetIndexEntrySize)
+	if err != nil {
+		return nil, err
+	}
+	idx := &offsetIndex{file: file, baseOffset: baseOffset}
+	for pos := 0; pos < len(data); pos += offsetIndexEntrySize {
+		idx.entries = append(idx.entries, offsetIndexEntry{
+			offset:   baseOffset + int64(binary.BigEndian.Uint32(data[pos:])),
+			position: int64(binary.BigEndian.Uint32(data[pos+4:])),
+		})
+	}
+	return idx, nil
+}
+
+func openTimeIndex(path string, baseOffset int64) (*timeIndex, error) {
+	file, data, err := openIndexFile(path, timeIndexEntrySize)
+	if err != nil {
+		return nil, err
+	}
+	idx := &timeIndex{file: file, baseOffset: baseOffset}
+	for pos := 0; pos < len(data); pos += timeIndexEntrySize {
+		idx.entries = append(idx.entries, timeIndexEntry{
+			timestamp: int64(binary.BigEndian.Uint64(data[pos:])),
+			offset:    baseOffset + int64(binary.BigEndian.Uint32(data[pos+8:])),
+		})
+	}
+	return idx, nil
+}
+
+// append adds an entry for the batch starting at position. Offsets must increase.
+func (idx *offsetIndex) append(offset, position int64) error {
+	if n := len(idx.entries); n > 0 && offset <= idx.entries[n-1].offset {
+		return nil
+	}
+	var entry [offsetIndexEntrySize]byte
+	binary.BigEndian.PutUint32(entry[0:], uint32(offset-idx.baseOffset))
+	binary.BigEndian.PutUint32(entry[4:], uint32(position))
+	if _, err := idx.file.Write(entry[:]); err != nil {
+		return fmt.Errorf("failed to append offset index entry: %w", err)
+	}
+	idx.entries = append(idx.entries, offsetIndexEntry{offset: offset, position: position})
+	return nil
+}
+
+// lookup returns the position of the last indexed batch starting at or before
+// offset, or 0 when there is none.
+func (idx *offsetIndex) lookup(offset int64) int64 {
+	i := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].offset > offset })
+	if i == 0 {
+		return 0
+	}
+	return idx.entries[i-1].position
+}
+
+// truncate drops all entries.
+func (idx *offsetIndex) truncate() error {
+	idx.entries = nil
+	return truncateFile(idx.file)
+}
+
+// maybeAppend adds an entry when timestamp is larger than the last indexed one.
+// Batches without timestamps (-1) are not indexed.
+func (idx *timeIndex) maybeAppend(timestamp, offset int64) error {
+	if timestamp < 0 {
+		return nil
+	}
+	if n := len(idx.entries); n > 0 && timestamp <= idx.entries[n-1].timestamp {
+		return nil
+	}
+	var entry [timeIndexEntrySize]byte
+	binary.BigEndian.PutUint64(entry[0:], uint64(timestamp))
+	binary.BigEndian.PutUint32(entry[8:], uint32(offset-idx.baseOffset))
+	if _, err := idx.file.Write(entry[:]); err != nil {
+		return fmt.Errorf("failed to append time index entry: %w", err)
+	}
+	idx.entries = append(idx.entries, timeIndexEntry{timestamp: timestamp, offset: offset})
+	return nil
+}
+
+// lookup returns the offset of the last entry with a timestamp below timestamp.
+// Every record before that offset has a smaller timestamp, so a search for the
+// first record at or after timestamp can start there.
+func (idx *timeIndex) lookup(timestamp int64) int64 {
+	i := sort.Search(len(idx.entries), func(i int) bool { return idx.entries[i].timestamp >= timestamp })
+	if i == 0 {
+		return idx.baseOffset
+	}
+	return idx.entries[i-1].offset
+}
+
+// truncate drops all entries.
+func (idx *timeIndex) truncate() error {
+	idx.entries = nil
+	return truncateFile(idx.file)
+}
+
+func truncateFile(file *os.File) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := file.Seek(0, 0)
+	return err
+}
@@ -0,0 +1,292 @@
+// Package storage implements partition logs: append-only sequences of record
+// batches split into segment files, laid out on disk like Kafka's.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// maxRelativeOffset is the largest offset delta the index files can hold.
+const maxRelativeOffset = math.MaxInt32
+
+// ErrOffsetOutOfRange is returned when reading an offset outside of the log.
+var ErrOffsetOutOfRange = errors.New("offset out of range")
+
+// errStopScan ends a segment scan early.
+var errStopScan = errors.New("stop scan")
+
+// Config controls how partition logs are split into segments and indexed.
+type Config struct {
+	// SegmentBytes is the size at which the active segment is rolled (log.segment.bytes).
+	SegmentBytes int64
+	// SegmentMs is the age at which the active segment is rolled (log.roll.ms).
+	SegmentMs time.Duration
+	// IndexIntervalBytes is how many bytes are written between index entries (log.index.interval.bytes).
+	IndexIntervalBytes int
+}
+
+// Log is the log of a single partition. Appends are serialized, reads may run
+// concurrently with each other.
+type Log struct {
+	dir string
+	cfg Config
+
+	mu sync.RWMutex
+	// segments are sorted by base offset; the last one is the active segment.
+	segments []*segment
+}
+
+// Open opens the partition log in dir, creating the directory and an empty
+// first segment if needed. The active segment is always rescanned and re-indexed,
+// since it may end with a torn batch; the others only when an index is missing.
+func Open(dir string, cfg Config) (*Log, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create partition directory: %w", err)
+	}
+	baseOffsets, err := segmentBaseOffsets(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(baseOffsets) == 0 {
+		baseOffsets = []int64{0}
+	}
+	l := &Log{dir: dir, cfg: cfg}
+	for i, baseOffset := range baseOffsets {
+		active := i == len(baseOffsets)-1
+		s, err := openSegment(dir, baseOffset, cfg, active)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l.segments = append(l.segments, s)
+	}
+	return l, nil
+}
+
+// segmentBaseOffsets returns the base offsets of the segment files in dir, in order.
+func segmentBaseOffsets(dir string) ([]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partition directory: %w", err)
+	}
+	var baseOffsets []int64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), logFileSuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		baseOffset, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		baseOffsets = append(baseOffsets, baseOffset)
+	}
+	sort.Slice(baseOffsets, func(i, j int) bool { return baseOffsets[i] < baseOffsets[j] })
+	return baseOffsets, nil
+}
+
+// Dir returns the directory of the log.
+func (l *Log) Dir() string {
+	return l.dir
+}
+
+// LogStartOffset returns the first offset in the log.
+func (l *Log) LogStartOffset() int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.segments[0].baseOffset
+}
+
+// LogEndOffset returns the offset the next appended record will get.
+func (l *Log) LogEndOffset() int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.active().nextOffset
+}
+
+// MaxTimestamp returns the largest batch timestamp in the log and the base
+// offset of the batch carrying it, or -1 and the log end offset if there is none.
+func (l *Log) MaxTimestamp() (timestamp, offset int64) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	timestamp, offset = -1, l.active().nextOffset
+	for _, s := range l.segments {
+		if s.maxTimestamp > timestamp {
+			timestamp, offset = s.maxTimestamp, s.offsetOfMaxTimestamp
+		}
+	}
+	return timestamp, offset
+}
+
+// TimestampSearchOffset returns the offset to start scanning from when looking
+// for the first record with a timestamp at or after timestamp. ok is false when
+// no batch in the log reaches timestamp.
+func (l *Log) TimestampSearchOffset(timestamp int64) (offset int64, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, s := range l.segments {
+		if s.maxTimestamp >= timestamp {
+			return s.timeIndex.lookup(timestamp), true
+		}
+	}
+	return 0, false
+}
+
+func (l *Log) active() *segment {
+	return l.segments[len(l.segments)-1]
+}
+
+// Append assigns offsets to the record batches in records, starting at the log
+// end offset, and appends them, rolling the active segment when it is full or
+// too old. The base offsets in records are overwritten. It returns the base
+// offset of the first batch.
+func (l *Log) Append(records []byte) (int64, error) {
+	batches, err := splitBatches(records)
+	if err != nil {
+		return 0, err
+	}
+	if len(batches) == 0 {
+		return 0, errors.New("no record batches")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	baseOffset := l.active().nextOffset
+	now := time.Now()
+	for _, batch := range batches {
+		nextOffset := l.active().nextOffset
+		setBatchBaseOffset(batch, nextOffset)
+		header, err := parseBatchHeader(batch)
+		if err != nil {
+			return 0, err
+		}
+		if l.active().shouldRoll(len(batch), header, l.cfg, now) {
+			if err := l.roll(nextOffset); err != nil {
+				return 0, fmt.Errorf("failed to roll segment: %w", err)
+			}
+		}
+		if err := l.active().append(batch, header, l.cfg); err != nil {
+			return 0, fmt.Errorf("failed to append to segment: %w", err)
+		}
+	}
+	if err := l.active().sync(); err != nil {
+		return 0, err
+	}
+	return baseOffset, nil
+}
+
+// roll closes the active segment for writes and starts a new one at baseOffset.
+// Callers must hold l.mu.
+func (l *Log) roll(baseOffset int64) error {
+	previous := l.active()
+	if err := previous.onBecomeInactive(); err != nil {
+		return err
+	}
+	if err := previous.sync(); err != nil {
+		return err
+	}
+	s, err := openSegment(l.dir, baseOffset, l.cfg, true)
+	if err != nil {
+		return err
+	}
+	l.segments = append(l.segments, s)
+	return nil
+}
+
+// Read returns the raw record batches starting with the batch that holds
+// offset, up to maxBytes. With minOneBatch set the first batch is returned even
+// if it is larger than maxBytes. Reading at the log end offset returns no data;
+// offsets before the log start offset or after the log end offset return
+// ErrOffsetOutOfRange.
+func (l *Log) Read(offset int64, maxBytes int, minOneBatch bool) ([]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if offset < l.segments[0].baseOffset || offset > l.active().nextOffset {
+		return nil, ErrOffsetOutOfRange
+	}
+	i := sort.Search(len(l.segments), func(i int) bool { return l.segments[i].baseOffset > offset }) - 1
+	for ; i < len(l.segments); i++ {
+		data, err := l.segments[i].readFrom(offset, maxBytes, minOneBatch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read segment: %w", err)
+		}
+		if len(data) > 0 || !minOneBatch {
+			return data, nil
+		}
+	}
+	return nil, nil
+}
+
+// ReadBatches decodes the record batches returned by Read, always including
+// the first one. Record values are kept as raw bytes.
+func (l *Log) ReadBatches(offset int64, maxBytes int) ([]metadata.RecordBatch, error) {
+	data, err := l.Read(offset, maxBytes, true)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := protocol.DecodeClusterMetadata(data, false)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.RecordBatchs, nil
+}
+
+// scanChunkBytes is how much of the log Scan reads at a time.
+const scanChunkBytes = 1 << 20
+
+// Scan decodes the record batches from offset to the end of the log, one chunk
+// at a time, and calls fn for each of them. With decodeValues set, record
+// values are decoded as KRaft metadata records.
+func (l *Log) Scan(offset int64, decodeValues bool, fn func(batch metadata.RecordBatch) error) error {
+	for {
+		data, err := l.Read(offset, scanChunkBytes, true)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		decoded, err := protocol.DecodeClusterMetadata(data, decodeValues)
+		if err != nil {
+			return err
+		}
+		for _, batch := range decoded.RecordBatchs {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			offset = batch.BaseOffset + int64(batch.LastOffsetDelta) + 1
+		}
+	}
+}
+
+// Close closes all segment files.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs []error
+	for _, s := range l.segments {
+		errs = append(errs, s.close())
+	}
+	return errors.Join(errs...)
+}
+
+// Delete closes the log and removes its directory.
+func (l *Log) Delete() error {
+	if err := l.Close(); err != nil {
+		return err
+	}
+	return os.RemoveAll(l.dir)
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol"
+)
+
+// LogManager places partition logs across the configured log directories
+// (log.dirs) and keeps them open. A partition lives in exactly one directory;
+// new partitions go to the directory holding the fewest partitions. The cluster
+// metadata log always lives in the first one.
+type LogManager struct {
+	dirs []string
+	cfg  Config
+
+	mu sync.Mutex
+	// placement maps a partition directory name ("topic-partition") to its log directory.
+	placement map[string]string
+	logs      map[string]*Log
+	// deleted holds the partitions removed by DeleteLog. Log does not recreate
+	// them, so a request that looked the partition up before it was deleted
+	// cannot leave an orphan directory behind; CreateLog does.
+	deleted map[string]bool
+}
+
+// ErrLogDeleted is returned by Log for a partition deleted by DeleteLog.
+var ErrLogDeleted = errors.New("partition log deleted")
+
+// NewLogManager scans the given log directories for existing partitions.
+// Directories that do not exist yet are created lazily when the first
+// partition is placed in them. Logs are opened on first use.
+func NewLogManager(dirs []string, cfg Config) (*LogManager, error) {
+	if len(dirs) == 0 {
+		return nil, errors.New("at least one log directory is required")
+	}
+	m := &LogManager{
+		dirs:      dirs,
+		cfg:       cfg,
+		placement: make(map[string]string),
+		logs:      make(map[string]*Log),
+		deleted:   make(map[string]bool),
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read log directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || !isPartitionDirName(entry.Name()) {
+				continue
+			}
+			if other, ok := m.placement[entry.Name()]; ok {
+				return nil, fmt.Errorf("duplicate log directory for %s found in %s and %s", entry.Name(), other, dir)
+			}
+			m.placement[entry.Name()] = dir
+		}
+	}
+	return m, nil
+}
+
+// Dirs returns the configured log directories.
+func (m *LogManager) Dirs() []string {
+	return m.dirs
+}
+
+// Log returns the log of a partition, opening it or creating it in the least
+// loaded log directory as needed. It returns ErrLogDeleted for a partition
+// deleted by DeleteLog and not created again since.
+func (m *LogManager) Log(topicName string, partitionIndex int32) (*Log, error) {
+	name := partitionDirName(topicName, partitionIndex)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.deleted[name] {
+		return nil, fmt.Errorf("failed to open log %s: %w", name, ErrLogDeleted)
+	}
+	return m.openLocked(name)
+}
+
+// CreateLog returns the log of a new partition, creating it like Log. A
+// partition of the same name deleted before is created again.
+func (m *LogManager) CreateLog(topicName string, partitionIndex int32) (*Log, error) {
+	name := partitionDirName(topicName, partitionIndex)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.deleted, name)
+	return m.openLocked(name)
+}
+
+// openLocked returns the open log of a partition, opening it as needed.
+// Callers must hold m.mu.
+func (m *LogManager) openLocked(name string) (*Log, error) {
+	if l, ok := m.logs[name]; ok {
+		return l, nil
+	}
+	dir, ok := m.placement[name]
+	if !ok {
+		dir = m.leastLoadedDir()
+	}
+	l, err := Open(filepath.Join(dir, name), m.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log %s: %w", name, err)
+	}
+	m.placement[name] = dir
+	m.logs[name] = l
+	return l, nil
+}
+
+// MetadataLog returns the log of the cluster metadata topic, which always
+// lives in the first log directory.
+func (m *LogManager) MetadataLog() (*Log, error) {
+	name := partitionDirName(protocol.ClusterMetadataTopic, 0)
+	m.mu.Lock()
+	if _, ok := m.placement[name]; !ok {
+		m.placement[name] = m.dirs[0]
+	}
+	m.mu.Unlock()
+	return m.Log(protocol.ClusterMetadataTopic, 0)
+}
+
+// DeleteLog closes the log of a partition and removes its directory with all
+// its data. Log no longer opens the partition afterwards.
+func (m *LogManager) DeleteLog(topicName string, partitionIndex int32) error {
+	name := partitionDirName(topicName, partitionIndex)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted[name] = true
+	dir, ok := m.placement[name]
+	if !ok {
+		return nil
+	}
+	if l, ok := m.logs[name]; ok {
+		if err := l.Close(); err != nil {
+			return err
+		}
+		delete(m.logs, name)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+		return err
+	}
+	delete(m.placement, name)
+	return nil
+}
+
+// Close closes all open logs.
+func (m *LogManager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var errs []error
+	for name, l := range m.logs {
+		errs = append(errs, l.Close())
+		delete(m.logs, name)
+	}
+	return errors.Join(errs...)
+}
+
+// leastLoadedDir returns the log directory holding the fewest partitions,
+// preferring earlier directories on ties. Callers must hold m.mu.
+func (m *LogManager) leastLoadedDir() string {
+	counts := make(map[string]int, len(m.dirs))
+	for _, dir := range m.placement {
+		counts[dir]++
+	}
+	best := m.dirs[0]
+	for _, dir := range m.dirs[1:] {
+		if counts[dir] < counts[best] {
+			best = dir
+		}
+	}
+	return best
+}
+
+func partitionDirName(topicName string, partitionIndex int32) string {
+	return fmt.Sprintf("%s-%d", topicName, partitionIndex)
+}
+
+// isPartitionDirName reports whether name looks like "topic-partition".
+func isPartitionDirName(name string) bool {
+	i := strings.LastIndexByte(name, '-')
+	if i <= 0 {
+		return false
+	}
+	_, err := strconv.ParseInt(name[i+1:], 10, 32)
+	return err == nil
+}
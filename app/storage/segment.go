@@ -0,0 +1,308 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codecrafters-io/kafka-starter-go/app/protocol/metadata"
+)
+
+// File name suffixes of the files making up a segment.
+const (
+	logFileSuffix       = ".log"
+	indexFileSuffix     = ".index"
+	timeIndexFileSuffix = ".timeindex"
+)
+
+// segment is one <baseOffset>.log file of a partition log together with its
+// offset and time indexes.
+type segment struct {
+	baseOffset int64
+	file       *os.File
+	size       int64
+	index      *offsetIndex
+	timeIndex  *timeIndex
+
+	// nextOffset is the offset following the last batch in the segment.
+	nextOffset int64
+	// maxTimestamp is the largest batch timestamp in the segment and
+	// offsetOfMaxTimestamp the base offset of the batch that carried it.
+	maxTimestamp         int64
+	offsetOfMaxTimestamp int64
+	// firstTimestamp is the timestamp of the first batch, or -1. Like Kafka,
+	// age based rolling compares it with the timestamp of new batches, and
+	// falls back to the time the segment was created when it is unknown.
+	firstTimestamp           int64
+	created                  time.Time
+	bytesSinceLastIndexEntry int
+}
+
+// segmentFileName returns the name of a segment file: the base offset padded to 20 digits.
+func segmentFileName(baseOffset int64, suffix string) string {
+	return fmt.Sprintf("%020d%s", baseOffset, suffix)
+}
+
+// openSegment opens (or creates) the segment starting at baseOffset. Existing
+// indexes are trusted unless recover is set or they are missing, in which case
+// the segment is scanned, truncated after its last complete batch and
+// re-indexed. recover is used for the active segment, which may end with a
+// batch that was only partly written when the broker stopped.
+func openSegment(dir string, baseOffset int64, cfg Config, recover bool) (*segment, error) {
+	logPath := filepath.Join(dir, segmentFileName(baseOffset, logFileSuffix))
+	indexPath := filepath.Join(dir, segmentFileName(baseOffset, indexFileSuffix))
+	timeIndexPath := filepath.Join(dir, segmentFileName(baseOffset, timeIndexFileSuffix))
+
+	_, indexErr := os.Stat(indexPath)
+	_, timeIndexErr := os.Stat(timeIndexPath)
+	if errors.Is(indexErr, os.ErrNotExist) || errors.Is(timeIndexErr, os.ErrNotExist) {
+		recover = true
+	}
+
+	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s := &segment{
+		baseOffset:           baseOffset,
+		file:                 file,
+		nextOffset:           baseOffset,
+		maxTimestamp:         -1,
+		offsetOfMaxTimestamp: baseOffset,
+		firstTimestamp:       -1,
+		created:              time.Now(),
+	}
+	if s.index, err = openOffsetIndex(indexPath, baseOffset); err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to open offset index: %w", err)
+	}
+	if s.timeIndex, err = openTimeIndex(timeIndexPath, baseOffset); err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to open time index: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	s.size = info.Size()
+
+	if recover {
+		err = s.recover(cfg)
+	} else {
+		err = s.loadTail()
+	}
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to load segment %s: %w", logPath, err)
+	}
+	return s, nil
+}
+
+// recover rebuilds both indexes by scanning every batch in the segment and
+// truncates a torn batch at its end. Batches failing their CRC check are kept:
+// they are reported as metadata.ErrCorruptBatch when read instead of being
+// dropped without notice.
+func (s *segment) recover(cfg Config) error {
+	if err := s.index.truncate(); err != nil {
+		return err
+	}
+	if err := s.timeIndex.truncate(); err != nil {
+		return err
+	}
+	s.bytesSinceLastIndexEntry = 0
+	validSize, err := s.scan(0, func(position int64, header batchHeader) error {
+		return s.indexBatch(position, header, cfg)
+	})
+	if err != nil {
+		return err
+	}
+	if validSize != s.size {
+		if err := s.file.Truncate(validSize); err != nil {
+			return err
+		}
+		s.size = validSize
+	}
+	return nil
+}
+
+// loadTail restores the in-memory state of a segment with intact indexes by
+// scanning the batches from the last offset index entry on, and reading the
+// header of the first batch for the segment's first timestamp.
+func (s *segment) loadTail() error {
+	var start int64
+	if n := len(s.index.entries); n > 0 {
+		start = s.index.entries[n-1].position
+	}
+	if n := len(s.timeIndex.entries); n > 0 {
+		last := s.timeIndex.entries[n-1]
+		s.maxTimestamp, s.offsetOfMaxTimestamp = last.timestamp, last.offset
+	}
+	validSize, err := s.scan(start, func(position int64, header batchHeader) error {
+		s.track(header)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if validSize != s.size {
+		return fmt.Errorf("segment has %d bytes after its last complete batch", s.size-validSize)
+	}
+	// The batches from the last index entry on were written since it was added.
+	s.bytesSinceLastIndexEntry = int(validSize - start)
+	// track took the first batch of the tail for the first of the segment.
+	s.firstTimestamp = -1
+	_, err = s.scan(0, func(position int64, header batchHeader) error {
+		s.firstTimestamp = header.maxTimestamp
+		return errStopScan
+	})
+	return err
+}
+
+// scan reads the batch headers from position to the end of the segment and
+// returns the position following the last complete batch. fn can end the scan
+// early by returning errStopScan, in which case the position of that batch is returned.
+func (s *segment) scan(position int64, fn func(position int64, header batchHeader) error) (int64, error) {
+	buf := make([]byte, metadata.BatchHeaderSize)
+	for position < s.size {
+		if _, err := s.file.ReadAt(buf, position); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, err
+		}
+		header, err := parseBatchHeader(buf)
+		if err != nil || position+int64(header.size) > s.size {
+			break
+		}
+		if err := fn(position, header); err != nil {
+			if errors.Is(err, errStopScan) {
+				break
+			}
+			return 0, err
+		}
+		position += int64(header.size)
+	}
+	return position, nil
+}
+
+// track updates the next offset and max timestamp after a batch was added.
+func (s *segment) track(header batchHeader) {
+	if s.nextOffset == s.baseOffset {
+		s.firstTimestamp = header.maxTimestamp
+	}
+	s.nextOffset = header.lastOffset + 1
+	if header.maxTimestamp > s.maxTimestamp {
+		s.maxTimestamp = header.maxTimestamp
+		s.offsetOfMaxTimestamp = header.baseOffset
+	}
+}
+
+// indexBatch records a batch written at position, adding index entries once
+// more than IndexIntervalBytes were written since the last one.
+func (s *segment) indexBatch(position int64, header batchHeader, cfg Config) error {
+	s.track(header)
+	if position == 0 || s.bytesSinceLastIndexEntry > cfg.IndexIntervalBytes {
+		if err := s.index.append(header.baseOffset, position); err != nil {
+			return err
+		}
+		if err := s.timeIndex.maybeAppend(s.maxTimestamp, s.offsetOfMaxTimestamp); err != nil {
+			return err
+		}
+		s.bytesSinceLastIndexEntry = 0
+	}
+	s.bytesSinceLastIndexEntry += header.size
+	return nil
+}
+
+// append writes one batch, whose base offset has already been assigned, to the end of the segment.
+func (s *segment) append(batch []byte, header batchHeader, cfg Config) error {
+	position := s.size
+	if _, err := s.file.WriteAt(batch, position); err != nil {
+		return err
+	}
+	s.size += int64(len(batch))
+	return s.indexBatch(position, header, cfg)
+}
+
+// sync flushes the segment file to disk.
+func (s *segment) sync() error {
+	return s.file.Sync()
+}
+
+// shouldRoll reports whether a batch must go to a new segment: the segment is
+// full, too old, or the offsets of the batch would no longer fit in the index.
+func (s *segment) shouldRoll(batchSize int, header batchHeader, cfg Config, now time.Time) bool {
+	if s.size == 0 {
+		return false
+	}
+	age := now.Sub(s.created)
+	if s.firstTimestamp >= 0 && header.maxTimestamp >= 0 {
+		age = time.Duration(header.maxTimestamp-s.firstTimestamp) * time.Millisecond
+	}
+	return s.size+int64(batchSize) > cfg.SegmentBytes ||
+		age >= cfg.SegmentMs ||
+		header.lastOffset-s.baseOffset > maxRelativeOffset
+}
+
+// onBecomeInactive records the final max timestamp of a segment that is rolled
+// over, so it can be restored from the time index alone.
+func (s *segment) onBecomeInactive() error {
+	if s.maxTimestamp < 0 {
+		return nil
+	}
+	return s.timeIndex.maybeAppend(s.maxTimestamp, s.offsetOfMaxTimestamp)
+}
+
+// readFrom returns the raw batches starting with the batch that holds offset,
+// stopping before maxBytes would be exceeded. Batches failing their CRC check
+// are reported as metadata.ErrCorruptBatch instead of being returned. With minOneBatch set the first
+// batch is returned even if it is larger, so that consumers can make progress.
+func (s *segment) readFrom(offset int64, maxBytes int, minOneBatch bool) ([]byte, error) {
+	start := int64(-1)
+	end := int64(0)
+	_, err := s.scan(s.index.lookup(offset), func(position int64, header batchHeader) error {
+		if header.lastOffset < offset {
+			return nil
+		}
+		if start < 0 {
+			if !minOneBatch && header.size > maxBytes {
+				return errStopScan
+			}
+			start = position
+		} else if position+int64(header.size)-start > int64(maxBytes) {
+			return errStopScan
+		}
+		end = position + int64(header.size)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 {
+		return nil, nil
+	}
+	data := make([]byte, end-start)
+	if _, err := s.file.ReadAt(data, start); err != nil {
+		return nil, err
+	}
+	if err := verifyBatches(data); err != nil {
+		return nil, fmt.Errorf("segment %s: %w", segmentFileName(s.baseOffset, logFileSuffix), err)
+	}
+	return data, nil
+}
+
+func (s *segment) close() error {
+	var errs []error
+	if s.index != nil {
+		errs = append(errs, s.index.file.Close())
+	}
+	if s.timeIndex != nil {
+		errs = append(errs, s.timeIndex.file.Close())
+	}
+	errs = append(errs, s.file.Close())
+	return errors.Join(errs...)
+}
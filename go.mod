@@ -4,7 +4,10 @@ go 1.24
 
 require (
 	github.com/google/uuid v1.6.0
+	github.com/klauspost/compress v1.18.0
 	github.com/lmittmann/tint v1.0.7
+	github.com/pierrec/lz4/v4 v4.1.30
+	github.com/spf13/pflag v1.0.6
 	github.com/spf13/viper v1.20.1
 )
 
@@ -16,7 +19,6 @@ require (
 	github.com/sourcegraph/conc v0.3.0 // indirect
 	github.com/spf13/afero v1.12.0 // indirect
 	github.com/spf13/cast v1.7.1 // indirect
-	github.com/spf13/pflag v1.0.6 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect